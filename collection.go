@@ -0,0 +1,55 @@
+package validol
+
+import (
+	"slices"
+)
+
+func Each[S ~[]E, E any](validate Validator[E], opts ...Option) Validator[S] {
+	cfg := newConfig(opts)
+	return func(s S) error {
+		errs := &collector{all: cfg.collectAll}
+		for i, e := range s {
+			if errs.add(validate(e), indexElem(i)) {
+				break
+			}
+		}
+		return errs.err()
+	}
+}
+
+func Keys[M ~map[K]V, K comparable, V any](validate Validator[K], opts ...Option) Validator[M] {
+	cfg := newConfig(opts)
+	return func(m M) error {
+		errs := &collector{all: cfg.collectAll}
+		for _, k := range sortedKeys(m) {
+			if errs.add(validate(k), mapKeyElem(k)) {
+				break
+			}
+		}
+		return errs.err()
+	}
+}
+
+func Values[M ~map[K]V, K comparable, V any](validate Validator[V], opts ...Option) Validator[M] {
+	cfg := newConfig(opts)
+	return func(m M) error {
+		errs := &collector{all: cfg.collectAll}
+		for _, k := range sortedKeys(m) {
+			if errs.add(validate(m[k]), mapValueElem(k)) {
+				break
+			}
+		}
+		return errs.err()
+	}
+}
+
+func sortedKeys[M ~map[K]V, K comparable, V any](m M) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.SortFunc(keys, func(a, b K) int {
+		return compareValues(toReflectValue(a), toReflectValue(b))
+	})
+	return keys
+}
@@ -0,0 +1,55 @@
+package validol_test
+
+import (
+	"testing"
+
+	vd "github.com/cospectrum/validol"
+	"github.com/stretchr/testify/assert"
+)
+
+type tags []string
+
+func TestEach(t *testing.T) {
+	t.Parallel()
+
+	each := vd.Each[tags](vd.Len[string](vd.Gt(1)))
+	assert.NoError(t, each(nil))
+	assert.NoError(t, each(tags{"ab", "cd"}))
+
+	verr := asValidationError(t, each(tags{"ab", "c", ""}))
+	assert.Equal(t, "[1]", verr.Path.String())
+
+	errs := vd.Errors(vd.Each[tags](vd.Len[string](vd.Gt(1)), vd.WithCollectAll())(tags{"ab", "c", ""}))
+	assert.Len(t, errs, 2)
+	assert.Equal(t, "[1]", errs[0].Path.String())
+	assert.Equal(t, "[2]", errs[1].Path.String())
+
+	field := vd.Field("Tags", func(s struct{ Tags tags }) tags { return s.Tags }, each)
+	verr = asValidationError(t, field(struct{ Tags tags }{Tags: tags{"a"}}))
+	assert.Equal(t, "Tags[0]", verr.Path.String())
+}
+
+func TestKeysAndValues(t *testing.T) {
+	t.Parallel()
+
+	stock := map[string]int{"sku-2": 0, "sku-1": -1, "3": 1}
+
+	keys := vd.Keys[map[string]int](vd.StartsWith("sku-"))
+	verr := asValidationError(t, keys(stock))
+	assert.Equal(t, `[key:"3"]`, verr.Path.String())
+	assert.NoError(t, keys(map[string]int{"sku-1": 1}))
+
+	values := vd.Values[map[string]int](vd.Gte(0))
+	verr = asValidationError(t, values(stock))
+	assert.Equal(t, `["sku-1"]`, verr.Path.String())
+	assert.NoError(t, values(map[string]int{"a": 0}))
+
+	errs := vd.Errors(vd.Values[map[string]int](vd.Gt(0), vd.WithCollectAll())(stock))
+	assert.Len(t, errs, 2)
+	assert.Equal(t, `["sku-1"]`, errs[0].Path.String())
+	assert.Equal(t, `["sku-2"]`, errs[1].Path.String())
+
+	dyn := vd.Keys[map[any]int](vd.NotNil[any], vd.WithCollectAll())
+	errs = vd.Errors(dyn(map[any]int{nil: 1, "a": 2, 1: 3}))
+	assert.Len(t, errs, 1)
+}
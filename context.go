@@ -0,0 +1,55 @@
+package validol
+
+import (
+	"context"
+	"errors"
+)
+
+type ValidatorCtx[Of any] func(context.Context, Of) error
+
+type ValidatableCtx interface {
+	ValidateCtx(ctx context.Context) error
+}
+
+func ValidateCtx[T any](ctx context.Context, t T, opts ...Option) error {
+	cfg := newConfig(opts)
+	if cfg.partial != nil {
+		return WalkCtx(ctx, t, opts...)
+	}
+	switch val := any(t).(type) {
+	case ValidatableCtx:
+		return val.ValidateCtx(cfg.withGroups(ctx))
+	case Validatable:
+		return val.Validate()
+	default:
+		return WalkCtx(ctx, t, opts...)
+	}
+}
+
+func WalkCtx[T any](ctx context.Context, t T, opts ...Option) error {
+	return newWalker(ctx, newConfig(opts)).walkDescendants(toReflectValue(t))
+}
+
+func Lift[T any](validate Validator[T]) ValidatorCtx[T] {
+	return func(_ context.Context, t T) error {
+		return validate(t)
+	}
+}
+
+func AllCtx[T any](validators ...ValidatorCtx[T]) ValidatorCtx[T] {
+	return func(ctx context.Context, t T) error {
+		for _, f := range validators {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := f(ctx, t); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func isContextErr(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,124 @@
+package validol_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	vd "github.com/cospectrum/validol"
+	"github.com/stretchr/testify/assert"
+)
+
+type username string
+
+var taken = map[username]bool{"admin": true}
+
+func (u username) ValidateCtx(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if taken[u] {
+		return errors.New("username is taken")
+	}
+	return nil
+}
+
+// Validate is ignored by the ctx-aware entry points.
+func (u username) Validate() error {
+	return errors.New("unreachable")
+}
+
+type signup struct {
+	Name  username
+	Email email
+}
+
+func TestValidateCtx(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	assert.NoError(t, vd.ValidateCtx(ctx, username("john")))
+	assert.Error(t, vd.ValidateCtx(ctx, username("admin")))
+
+	assert.NoError(t, vd.ValidateCtx(ctx, signup{Name: "john", Email: "a@b.c"}))
+	verr := asValidationError(t, vd.ValidateCtx(ctx, signup{Name: "admin", Email: "a@b.c"}))
+	assert.Equal(t, "Name", verr.Path.String())
+	verr = asValidationError(t, vd.WalkCtx(ctx, []signup{{Name: "john"}}))
+	assert.Equal(t, "[0].Email", verr.Path.String())
+
+	assert.Error(t, vd.ValidateCtx(ctx, email("invalid")))
+
+	// plain Walk uses a background context
+	assert.NoError(t, vd.Walk(signup{Name: "john", Email: "a@b.c"}))
+}
+
+func TestWalkCtxCancelled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	in := []signup{{Name: "john", Email: "invalid"}, {Name: "admin"}}
+	for _, err := range []error{
+		vd.WalkCtx(ctx, in),
+		vd.WalkCtx(ctx, in, vd.WithCollectAll()),
+		vd.ValidateCtx(ctx, in),
+	} {
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Empty(t, vd.Errors(err)[0].Path)
+	}
+
+	calls := 0
+	count := func(context.Context, int) error {
+		calls++
+		return nil
+	}
+	err := vd.AllCtx(count, count)(ctx, 1)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 0, calls)
+}
+
+// lookup times out on its own, e.g. a per-call timeout of a remote check.
+type lookup string
+
+func (l lookup) ValidateCtx(ctx context.Context) error {
+	if l == "slow" {
+		return context.DeadlineExceeded
+	}
+	return nil
+}
+
+func TestWalkCtxValidatorTimeout(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	err := vd.WalkCtx(ctx, []lookup{"slow", "fast", "slow"}, vd.WithCollectAll())
+	errs := vd.Errors(err)
+	if assert.Len(t, errs, 2) {
+		assert.Equal(t, "[0]", errs[0].Path.String())
+		assert.Equal(t, "[2]", errs[1].Path.String())
+	}
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	done := make(chan struct{})
+	slow := func(context.Context, int) error {
+		defer close(done)
+		return context.DeadlineExceeded
+	}
+	invalid := func(context.Context, int) error {
+		<-done
+		return errors.New("invalid")
+	}
+	err = vd.ParallelCtx(slow, invalid)(ctx, 0)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestAllCtx(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	positive := vd.AllCtx(vd.Lift(vd.Gt(0)), vd.Lift(vd.Lt(10)))
+	assert.NoError(t, positive(ctx, 1))
+	assert.Error(t, positive(ctx, 0))
+	assert.Error(t, positive(ctx, 10))
+}
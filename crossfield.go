@@ -0,0 +1,171 @@
+package validol
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+)
+
+func EqField[T any, F comparable](field string, get func(T) F, other string, getOther func(T) F) Validator[T] {
+	return compareFields("EqField", func(a, b F) bool { return a == b }, field, get, other, getOther)
+}
+
+func NeField[T any, F comparable](field string, get func(T) F, other string, getOther func(T) F) Validator[T] {
+	return compareFields("NeField", func(a, b F) bool { return a != b }, field, get, other, getOther)
+}
+
+func GtField[T any, F cmp.Ordered](field string, get func(T) F, other string, getOther func(T) F) Validator[T] {
+	return compareFields("GtField", func(a, b F) bool { return a > b }, field, get, other, getOther)
+}
+
+func GteField[T any, F cmp.Ordered](field string, get func(T) F, other string, getOther func(T) F) Validator[T] {
+	return compareFields("GteField", func(a, b F) bool { return a >= b }, field, get, other, getOther)
+}
+
+func LtField[T any, F cmp.Ordered](field string, get func(T) F, other string, getOther func(T) F) Validator[T] {
+	return compareFields("LtField", func(a, b F) bool { return a < b }, field, get, other, getOther)
+}
+
+func LteField[T any, F cmp.Ordered](field string, get func(T) F, other string, getOther func(T) F) Validator[T] {
+	return compareFields("LteField", func(a, b F) bool { return a <= b }, field, get, other, getOther)
+}
+
+func compareFields[T, F any](
+	rule string, ok func(a, b F) bool,
+	field string, get func(T) F,
+	other string, getOther func(T) F,
+) Validator[T] {
+	elem := fieldElemOf[T](field)
+	return func(t T) error {
+		val, otherVal := get(t), getOther(t)
+		if ok(val, otherVal) {
+			return nil
+		}
+		return withPath(fieldMismatch(rule, val, other, otherVal), elem)
+	}
+}
+
+func RequiredIf[T, F any, C comparable](field string, get func(T) F, other string, getOther func(T) C, val C) Validator[T] {
+	elem := fieldElemOf[T](field)
+	return func(t T) error {
+		if getOther(t) != val {
+			return nil
+		}
+		if err := requiredIf(get(t), other, val); err != nil {
+			return withPath(err, elem)
+		}
+		return nil
+	}
+}
+
+func RequiredWith[T, F, O any](field string, get func(T) F, other string, getOther func(T) O) Validator[T] {
+	elem := fieldElemOf[T](field)
+	return func(t T) error {
+		if isEmpty(getOther(t)) {
+			return nil
+		}
+		if err := requiredWith(get(t), other); err != nil {
+			return withPath(err, elem)
+		}
+		return nil
+	}
+}
+
+func fieldMismatch(rule string, val any, other string, otherVal any) error {
+	return failed(rule, val, map[string]any{"field": other, "val": otherVal},
+		fmt.Sprintf("validol.%s(%s=%+v)(%+v)", rule, other, otherVal, val))
+}
+
+func requiredIf[F any](t F, other string, val any) error {
+	if !isEmpty(t) {
+		return nil
+	}
+	return failed("RequiredIf", t, map[string]any{"field": other, "val": val},
+		fmt.Sprintf("validol.RequiredIf(%s=%+v)(%+v)", other, val, t))
+}
+
+func requiredWith[F any](t F, other string) error {
+	if !isEmpty(t) {
+		return nil
+	}
+	return failed("RequiredWith", t, map[string]any{"field": other},
+		fmt.Sprintf("validol.RequiredWith(%s)(%+v)", other, t))
+}
+
+func siblingField(parent reflect.Type, name string) (reflect.StructField, error) {
+	sf, ok := parent.FieldByName(name)
+	if !ok || !sf.IsExported() || len(sf.Index) != 1 {
+		return sf, fmt.Errorf("unknown field %q", name)
+	}
+	return sf, nil
+}
+
+func crossFieldRule(rule string, ordered bool, ok func(c int) bool) ruleFactory {
+	return func(parent reflect.Type, sf reflect.StructField, params []string) (fieldRule, error) {
+		name, err := singleParam(params)
+		if err != nil {
+			return nil, err
+		}
+		other, err := siblingField(parent, name)
+		if err != nil {
+			return nil, err
+		}
+		if other.Type != sf.Type {
+			return nil, fmt.Errorf("field %q has type %s, expected %s", name, other.Type, sf.Type)
+		}
+		if !sf.Type.Comparable() || (ordered && !isOrderedKind(sf.Type.Kind())) {
+			return nil, notApplicable(sf.Type)
+		}
+		return func(parent, field reflect.Value) error {
+			otherVal := parent.FieldByIndex(other.Index)
+			var c int
+			if ordered {
+				c = compareValues(field, otherVal)
+			} else if !field.Equal(otherVal) {
+				c = 1
+			}
+			if ok(c) {
+				return nil
+			}
+			return fieldMismatch(rule, field, name, otherVal.Interface())
+		}, nil
+	}
+}
+
+func requiredIfRule(parent reflect.Type, _ reflect.StructField, params []string) (fieldRule, error) {
+	if len(params) != 2 {
+		return nil, fmt.Errorf("expected 2 parameters, got %d", len(params))
+	}
+	name := params[0]
+	other, err := siblingField(parent, name)
+	if err != nil {
+		return nil, err
+	}
+	val, err := parseParam(other.Type, params[1])
+	if err != nil {
+		return nil, err
+	}
+	return func(parent, field reflect.Value) error {
+		if parent.FieldByIndex(other.Index).Interface() != val {
+			return nil
+		}
+		return requiredIf(field, name, val)
+	}, nil
+}
+
+func requiredWithRule(parent reflect.Type, _ reflect.StructField, params []string) (fieldRule, error) {
+	name, err := singleParam(params)
+	if err != nil {
+		return nil, err
+	}
+	other, err := siblingField(parent, name)
+	if err != nil {
+		return nil, err
+	}
+	return func(parent, field reflect.Value) error {
+		if isEmpty(parent.FieldByIndex(other.Index)) {
+			return nil
+		}
+		return requiredWith(field, name)
+	}, nil
+}
@@ -0,0 +1,124 @@
+package validol_test
+
+import (
+	"errors"
+	"testing"
+
+	vd "github.com/cospectrum/validol"
+	"github.com/stretchr/testify/assert"
+)
+
+type period struct {
+	Start int
+	End   int
+}
+
+func (p period) Validate() error {
+	return vd.GtField(
+		"End", func(p period) int { return p.End },
+		"Start", func(p period) int { return p.Start },
+	)(p)
+}
+
+type contact struct {
+	Method string
+	Phone  string
+	Email  string
+	Name   string
+}
+
+func (c contact) Validate() error {
+	return vd.All(
+		vd.RequiredIf(
+			"Phone", func(c contact) string { return c.Phone },
+			"Method", func(c contact) string { return c.Method }, "sms",
+		),
+		vd.RequiredWith(
+			"Name", func(c contact) string { return c.Name },
+			"Email", func(c contact) string { return c.Email },
+		),
+	)(c)
+}
+
+func TestCrossField(t *testing.T) {
+	t.Parallel()
+
+	start := func(p period) int { return p.Start }
+	end := func(p period) int { return p.End }
+
+	assert.NoError(t, vd.EqField("End", end, "Start", start)(period{1, 1}))
+	assert.Error(t, vd.EqField("End", end, "Start", start)(period{1, 2}))
+	assert.NoError(t, vd.NeField("End", end, "Start", start)(period{1, 2}))
+	assert.Error(t, vd.NeField("End", end, "Start", start)(period{1, 1}))
+	assert.NoError(t, vd.GteField("End", end, "Start", start)(period{1, 1}))
+	assert.Error(t, vd.GteField("End", end, "Start", start)(period{2, 1}))
+	assert.NoError(t, vd.LtField("Start", start, "End", end)(period{1, 2}))
+	assert.Error(t, vd.LtField("Start", start, "End", end)(period{1, 1}))
+	assert.NoError(t, vd.LteField("Start", start, "End", end)(period{1, 1}))
+	assert.Error(t, vd.LteField("Start", start, "End", end)(period{2, 1}))
+
+	assert.NoError(t, vd.ValidateWith(period{1, 2}, vd.WithTags()))
+	err := vd.ValidateWith([]period{{1, 2}, {2, 2}}, vd.WithTags())
+	verr := asValidationError(t, err)
+	assert.Equal(t, "[1].End", verr.Path.String())
+	assert.Equal(t, "GtField", verr.Rule)
+	assert.Equal(t, map[string]any{"field": "Start", "val": 2}, verr.Params)
+	assert.Equal(t, "[1].End: validol.GtField(Start=2)(2) failed", err.Error())
+
+	assert.NoError(t, vd.ValidateWith(contact{Method: "email"}, vd.WithTags()))
+	assert.NoError(t, vd.ValidateWith(contact{Method: "sms", Phone: "123"}, vd.WithTags()))
+	verr = asValidationError(t, vd.ValidateWith(contact{Method: "sms"}, vd.WithTags()))
+	assert.Equal(t, "Phone", verr.Path.String())
+	assert.Equal(t, "RequiredIf", verr.Rule)
+
+	assert.NoError(t, vd.ValidateWith(contact{Email: "a@b.c", Name: "a"}, vd.WithTags()))
+	verr = asValidationError(t, vd.ValidateWith(contact{Email: "a@b.c"}, vd.WithTags()))
+	assert.Equal(t, "Name", verr.Path.String())
+	assert.Equal(t, "RequiredWith", verr.Rule)
+}
+
+type taggedPeriod struct {
+	Start    int `validate:"lte_field=End"`
+	End      int
+	Password string
+	Confirm  string `validate:"eq_field=Password"`
+	Method   string
+	Phone    string `validate:"required_if=Method sms"`
+	Email    string
+	Name     string `validate:"required_with=Email"`
+}
+
+func TestCrossFieldTags(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, vd.WalkWith(taggedPeriod{Start: 1, End: 2}, vd.WithTags()))
+
+	cases := []struct {
+		in   taggedPeriod
+		path string
+		rule string
+	}{
+		{taggedPeriod{Start: 2, End: 1}, "Start", "LteField"},
+		{taggedPeriod{Password: "a"}, "Confirm", "EqField"},
+		{taggedPeriod{Method: "sms"}, "Phone", "RequiredIf"},
+		{taggedPeriod{Email: "a@b.c"}, "Name", "RequiredWith"},
+	}
+	for _, tc := range cases {
+		verr := asValidationError(t, vd.WalkWith(tc.in, vd.WithTags()))
+		assert.Equal(t, tc.path, verr.Path.String())
+		assert.Equal(t, tc.rule, verr.Rule)
+	}
+
+	var tagErr *vd.TagError
+	assert.True(t, errors.As(vd.WalkWith(struct {
+		A int `validate:"gt_field=B"`
+	}{}, vd.WithTags()), &tagErr))
+	assert.True(t, errors.As(vd.WalkWith(struct {
+		A int `validate:"gt_field=B"`
+		B string
+	}{}, vd.WithTags()), &tagErr))
+	assert.True(t, errors.As(vd.WalkWith(struct {
+		A int `validate:"required_if=B"`
+		B string
+	}{}, vd.WithTags()), &tagErr))
+}
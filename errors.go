@@ -0,0 +1,123 @@
+package validol
+
+import (
+	"errors"
+	"reflect"
+)
+
+type ValidationError struct {
+	Rule   string
+	Code   string
+	Params map[string]any
+	Value  any
+	Path   Path
+	Err    error
+	// Message replaces the generated message, see WithMessage.
+	Message string
+
+	expr string
+}
+
+var _ error = &ValidationError{}
+
+func (e *ValidationError) Error() string {
+	msg := e.message()
+	if len(e.Path) == 0 {
+		return msg
+	}
+	return e.Path.String() + ": " + msg
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+func (e *ValidationError) message() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	if e.expr == "" && e.Err != nil {
+		if inner, ok := e.Err.(*ValidationError); ok { //nolint:errorlint // the path of a direct cause is already reported
+			return inner.message()
+		}
+		return e.Err.Error()
+	}
+	return e.expr + " failed"
+}
+
+func failed(rule string, value any, params map[string]any, expr string) error {
+	if val, ok := value.(reflect.Value); ok && val.CanInterface() {
+		value = val.Interface()
+	}
+	return &ValidationError{
+		Rule:   rule,
+		Code:   snakeCase(rule),
+		Params: params,
+		Value:  value,
+		expr:   expr,
+	}
+}
+
+func Errors(err error) []*ValidationError {
+	if err == nil {
+		return nil
+	}
+	switch e := err.(type) { //nolint:errorlint // walks the error tree manually
+	case *ValidationError:
+		return []*ValidationError{e}
+	case interface{ Unwrap() []error }:
+		var out []*ValidationError
+		for _, err := range e.Unwrap() {
+			out = append(out, Errors(err)...)
+		}
+		return out
+	case interface{ Unwrap() error }:
+		if inner := Errors(e.Unwrap()); len(inner) > 0 {
+			return inner
+		}
+	}
+	return []*ValidationError{{Err: err}}
+}
+
+// withPath prepends elem to the path of every validation error in err.
+// Errors that are not produced by validol are wrapped into a *ValidationError.
+func withPath(err error, elem PathElem) error {
+	if err == nil {
+		return nil
+	}
+	switch e := err.(type) { //nolint:errorlint // only the outermost error is annotated
+	case *ValidationError:
+		out := *e
+		out.Path = append(Path{elem}, e.Path...)
+		return &out
+	case interface{ Unwrap() []error }:
+		errs := e.Unwrap()
+		out := make([]error, 0, len(errs))
+		for _, err := range errs {
+			out = append(out, withPath(err, elem))
+		}
+		return joinErrors(out)
+	default:
+		return &ValidationError{
+			Path: Path{elem},
+			Err:  err,
+		}
+	}
+}
+
+// joinErrors is errors.Join that flattens nested multi-errors,
+// so that the result has one entry per failure.
+func joinErrors(errs []error) error {
+	if len(errs) == 1 {
+		return errs[0]
+	}
+	flat := make([]error, 0, len(errs))
+	for _, err := range errs {
+		if multi, ok := err.(interface{ Unwrap() []error }); ok { //nolint:errorlint // only direct multi-errors are flattened
+			flat = append(flat, multi.Unwrap()...)
+			continue
+		}
+		flat = append(flat, err)
+	}
+	return errors.Join(flat...)
+}
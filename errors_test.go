@@ -0,0 +1,122 @@
+package validol_test
+
+import (
+	"errors"
+	"testing"
+
+	vd "github.com/cospectrum/validol"
+	"github.com/stretchr/testify/assert"
+)
+
+func asValidationError(t *testing.T, err error) *vd.ValidationError {
+	t.Helper()
+
+	var verr *vd.ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected *ValidationError, got %T", err)
+	}
+	return verr
+}
+
+func TestValidationError(t *testing.T) {
+	t.Parallel()
+
+	verr := asValidationError(t, vd.Gt(5)(3))
+	assert.Equal(t, "Gt", verr.Rule)
+	assert.Equal(t, map[string]any{"val": 5}, verr.Params)
+	assert.Equal(t, 3, verr.Value)
+	assert.Empty(t, verr.Path)
+	assert.Equal(t, "validol.Gt(5)(3) failed", verr.Error())
+
+	verr = asValidationError(t, vd.OneOf("a", "b")("c"))
+	assert.Equal(t, "OneOf", verr.Rule)
+	assert.Equal(t, map[string]any{"vals": []string{"a", "b"}}, verr.Params)
+	assert.Equal(t, "c", verr.Value)
+
+	verr = asValidationError(t, vd.Email("invalid"))
+	assert.Equal(t, "Email", verr.Rule)
+	assert.Nil(t, verr.Params)
+	assert.Equal(t, "invalid", verr.Value)
+	assert.Equal(t, `validol.Email("invalid") failed`, verr.Error())
+
+	verr = asValidationError(t, vd.Not(vd.Required[int])(1))
+	assert.Equal(t, "Not", verr.Rule)
+	assert.Equal(t, 1, verr.Value)
+
+	verr = asValidationError(t, vd.Len[string](vd.Lte(1))("abc"))
+	assert.Equal(t, "Lte", verr.Rule)
+	assert.Equal(t, 3, verr.Value)
+}
+
+func TestPathString(t *testing.T) {
+	t.Parallel()
+
+	path := vd.Path{
+		{Kind: vd.PathField, Name: "Users"},
+		{Kind: vd.PathIndex, Index: 3},
+		{Kind: vd.PathField, Name: "Email"},
+	}
+	assert.Equal(t, "Users[3].Email", path.String())
+
+	path = vd.Path{
+		{Kind: vd.PathField, Name: "Items"},
+		{Kind: vd.PathMapValue, Key: "sku-1"},
+		{Kind: vd.PathField, Name: "Qty"},
+	}
+	assert.Equal(t, `Items["sku-1"].Qty`, path.String())
+
+	path = vd.Path{
+		{Kind: vd.PathMapKey, Key: 7},
+	}
+	assert.Equal(t, "[key:7]", path.String())
+}
+
+type pointerItem struct {
+	Qty qty `json:"qty,omitempty"`
+}
+
+type pointerOrder struct {
+	PatchBase
+	Items   map[string]pointerItem `json:"items"`
+	Ignored []pointerItem          `json:"-"`
+	Lines   []pointerItem
+}
+
+func TestPathJSONPointer(t *testing.T) {
+	t.Parallel()
+
+	path := vd.Path{
+		{Kind: vd.PathField, Name: "Users", Tag: `json:"users"`},
+		{Kind: vd.PathIndex, Index: 3},
+		{Kind: vd.PathField, Name: "Email"},
+		{Kind: vd.PathMapValue, Key: "a/b~c"},
+	}
+	assert.Equal(t, "/users/3/Email/a~1b~0c", path.JSONPointer())
+	assert.Equal(t, "", vd.Path{}.JSONPointer())
+
+	path = vd.Path{
+		{Kind: vd.PathField, Name: "Items", Tag: `json:"items"`},
+		{Kind: vd.PathMapValue, Key: "a"},
+		{Kind: vd.PathField, Name: "Secret", Tag: `json:"-"`},
+		{Kind: vd.PathField, Name: "Value"},
+	}
+	assert.Equal(t, "/items/a", path.JSONPointer())
+	path = vd.Path{{Kind: vd.PathField, Name: "Dash", Tag: `json:"-,"`}}
+	assert.Equal(t, "/-", path.JSONPointer())
+
+	in := pointerOrder{
+		Items:   map[string]pointerItem{"sku-1": {Qty: 0}},
+		Ignored: []pointerItem{{}},
+		Lines:   []pointerItem{{Qty: 1}, {}},
+	}
+	var pointers []string
+	for _, verr := range vd.Errors(vd.WalkWith(in, vd.WithCollectAll(), vd.WithTags())) {
+		pointers = append(pointers, verr.Path.JSONPointer())
+	}
+	assert.Equal(t, []string{"/name", "/items/sku-1/qty", "", "/Lines/1/qty"}, pointers)
+
+	name := vd.Field("Name", func(o pointerOrder) string { return o.Name }, vd.Required)
+	assert.Equal(t, "/name", asValidationError(t, name(pointerOrder{})).Path.JSONPointer())
+	qty := vd.Field("Qty", func(i *pointerItem) qty { return i.Qty }, qty.Validate)
+	assert.Equal(t, "/qty", asValidationError(t, qty(&pointerItem{})).Path.JSONPointer())
+}
@@ -0,0 +1,21 @@
+package validol
+
+func Field[T, F any](name string, get func(T) F, validators ...Validator[F]) Validator[T] {
+	validate := All(validators...)
+	elem := fieldElemOf[T](name)
+	return func(t T) error {
+		return withPath(validate(get(t)), elem)
+	}
+}
+
+func Struct[T any](rules ...Validator[T]) Validator[T] {
+	return func(t T) error {
+		errs := make([]error, 0)
+		for _, rule := range rules {
+			if err := rule(t); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		return joinErrors(errs)
+	}
+}
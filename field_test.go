@@ -0,0 +1,63 @@
+package validol_test
+
+import (
+	"testing"
+
+	vd "github.com/cospectrum/validol"
+	"github.com/stretchr/testify/assert"
+)
+
+type account struct {
+	Email    string
+	Age      int
+	Nickname string
+	Period   period
+}
+
+var validateAccount = vd.Struct(
+	vd.Field("Email", func(a account) string { return a.Email }, vd.Email),
+	vd.Field("Age", func(a account) int { return a.Age }, vd.Gte(18), vd.Lt(150)),
+	vd.Field("Nickname", func(a account) string { return a.Nickname }),
+	vd.Field("Period", func(a account) period { return a.Period }, vd.Validate[period]),
+)
+
+func (a account) Validate() error {
+	return validateAccount(a)
+}
+
+func TestField(t *testing.T) {
+	t.Parallel()
+
+	email := vd.Field("Email", func(a account) string { return a.Email }, vd.Email)
+	assert.NoError(t, email(account{Email: "a@b.c"}))
+
+	err := email(account{Email: "invalid"})
+	verr := asValidationError(t, err)
+	assert.Equal(t, "Email", verr.Path.String())
+	assert.Equal(t, "Email", verr.Rule)
+	assert.Equal(t, `Email: validol.Email("invalid") failed`, err.Error())
+
+	assert.NoError(t, vd.Field("Email", func(a account) string { return a.Email })(account{}))
+}
+
+func TestStruct(t *testing.T) {
+	t.Parallel()
+
+	valid := account{Email: "a@b.c", Age: 18, Period: period{Start: 1, End: 2}}
+	assert.NoError(t, vd.Validate(valid))
+
+	errs := vd.Errors(vd.Validate(account{Email: "a@b.c", Age: 150}))
+	assert.Len(t, errs, 2)
+	assert.Equal(t, "Age", errs[0].Path.String())
+	assert.Equal(t, "Lt", errs[0].Rule)
+	assert.Equal(t, "Period.End", errs[1].Path.String())
+
+	errs = vd.Errors(vd.Validate([]account{valid, {}}))
+	paths := make([]string, 0)
+	for _, verr := range errs {
+		paths = append(paths, verr.Path.String())
+	}
+	assert.Equal(t, []string{"[1].Email", "[1].Age", "[1].Period.End"}, paths)
+
+	assert.NoError(t, vd.Struct[account]()(account{}))
+}
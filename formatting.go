@@ -2,29 +2,41 @@ package validol
 
 import (
 	"fmt"
+	"reflect"
+	"strconv"
 	"strings"
+	"unicode"
 )
 
-//nolint:errname // struct is private
-type failedExpr struct {
-	expr string
+func fmtVarargs[T any](elems []T) string {
+	toStr := func(el T) string {
+		return fmt.Sprintf("%+v", el)
+	}
+	return strings.Join(mapF(elems, toStr), ", ")
 }
 
-var _ error = &failedExpr{}
-
-func (e failedExpr) Error() string {
-	return e.expr + " failed"
+func fmtIndex(i int) string {
+	return "[" + strconv.Itoa(i) + "]"
 }
 
-func failed(expr string) error {
-	return failedExpr{expr: expr}
+func fmtKey(key any) string {
+	if reflect.ValueOf(key).Kind() == reflect.String {
+		return fmt.Sprintf("%q", key)
+	}
+	return fmt.Sprintf("%+v", key)
 }
 
-func fmtVarargs[T any](elems []T) string {
-	toStr := func(el T) string {
-		return fmt.Sprintf("%+v", el)
+// snakeCase converts a rule name to a code, e.g. OneOf to one_of and UUID4 to uuid4.
+func snakeCase(s string) string {
+	var b strings.Builder
+	runes := []rune(s)
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) && (!unicode.IsUpper(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToLower(r))
 	}
-	return strings.Join(mapF(elems, toStr), ", ")
+	return b.String()
 }
 
 func mapF[T any, U any](elems []T, f func(T) U) []U {
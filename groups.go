@@ -0,0 +1,62 @@
+package validol
+
+import (
+	"context"
+	"slices"
+)
+
+// DefaultGroup is active when no groups are requested.
+const DefaultGroup = "default"
+
+type groupsKey struct{}
+
+func Groups(groups ...string) Option {
+	return func(c *config) {
+		c.groups = append(c.groups, groups...)
+	}
+}
+
+func ContextWithGroups(ctx context.Context, groups ...string) context.Context {
+	return context.WithValue(ctx, groupsKey{}, slices.Clone(groups))
+}
+
+// ActiveGroups returns the validation groups of the context.
+func ActiveGroups(ctx context.Context) []string {
+	return slices.Clone(activeGroups(ctx))
+}
+
+// InGroups runs validate only if one of the groups is active.
+func InGroups[T any](validate Validator[T], groups ...string) ValidatorCtx[T] {
+	return func(ctx context.Context, t T) error {
+		if !anyActive(activeGroups(ctx), groups) {
+			return nil
+		}
+		return validate(t)
+	}
+}
+
+var defaultGroups = []string{DefaultGroup}
+
+func activeGroups(ctx context.Context) []string {
+	if groups, ok := ctx.Value(groupsKey{}).([]string); ok && len(groups) > 0 {
+		return groups
+	}
+	return defaultGroups
+}
+
+func anyActive(active, groups []string) bool {
+	for _, group := range groups {
+		if slices.Contains(active, group) {
+			return true
+		}
+	}
+	return false
+}
+
+// withGroups returns ctx with the groups of the configuration, if any.
+func (c config) withGroups(ctx context.Context) context.Context {
+	if len(c.groups) == 0 {
+		return ctx
+	}
+	return ContextWithGroups(ctx, c.groups...)
+}
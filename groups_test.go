@@ -0,0 +1,82 @@
+package validol_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	vd "github.com/cospectrum/validol"
+	"github.com/stretchr/testify/assert"
+)
+
+type userDTO struct {
+	ID   string `json:"id" validate.create:"empty" validate.update:"uuid4"`
+	Name string `validate:"required"`
+	Role string `validate.admin:"required,one_of=admin owner"`
+}
+
+type profile struct {
+	Nickname string
+	User     userDTO
+}
+
+func (p profile) ValidateCtx(ctx context.Context) error {
+	if slices.Contains(vd.ActiveGroups(ctx), "admin") && p.Nickname == "" {
+		return vd.Required(p.Nickname)
+	}
+	return vd.AllCtx(
+		vd.InGroups(vd.Field("Nickname", func(p profile) string { return p.Nickname }, vd.Len[string](vd.Gte(3))), "update"),
+		func(ctx context.Context, p profile) error { return vd.WalkCtx(ctx, p, vd.WithTags()) },
+	)(ctx, p)
+}
+
+func TestGroupTags(t *testing.T) {
+	t.Parallel()
+
+	const id = "57b73598-8764-4ad0-a76a-679bb6640eb1"
+	assert.NoError(t, vd.ValidateWith(userDTO{Name: "john"}, vd.WithTags()))
+	assert.NoError(t, vd.ValidateWith(userDTO{ID: "1", Name: "john"}, vd.WithTags()))
+	assert.NoError(t, vd.ValidateWith(userDTO{Name: "john"}, vd.Groups("create"), vd.WithTags()))
+	assert.NoError(t, vd.ValidateWith(userDTO{ID: id, Name: "john"}, vd.Groups("update"), vd.WithTags()))
+	assert.NoError(t, vd.ValidateWith(userDTO{ID: id, Name: "john", Role: "admin"}, vd.Groups("update", "admin"), vd.WithTags()))
+
+	verr := asValidationError(t, vd.ValidateWith(userDTO{ID: id, Name: "john"}, vd.Groups("create"), vd.WithTags()))
+	assert.Equal(t, "ID", verr.Path.String())
+	assert.Equal(t, "Empty", verr.Rule)
+	verr = asValidationError(t, vd.ValidateWith(userDTO{Name: "john"}, vd.Groups("update"), vd.WithTags()))
+	assert.Equal(t, "UUID4", verr.Rule)
+	verr = asValidationError(t, vd.ValidateWith(userDTO{ID: id}, vd.Groups("update"), vd.WithTags()))
+	assert.Equal(t, "Name", verr.Path.String())
+
+	errs := vd.Errors(vd.ValidateWith([]userDTO{{Role: "user"}}, vd.Groups("update", "admin"), vd.WithCollectAll(), vd.WithTags()))
+	paths := make([]string, 0, len(errs))
+	for _, verr := range errs {
+		paths = append(paths, verr.Path.String())
+	}
+	assert.Equal(t, []string{"[0].ID", "[0].Name", "[0].Role"}, paths)
+}
+
+func TestGroupsContext(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	assert.Equal(t, []string{vd.DefaultGroup}, vd.ActiveGroups(ctx))
+	ctx = vd.ContextWithGroups(ctx, "update", "admin")
+	assert.Equal(t, []string{"update", "admin"}, vd.ActiveGroups(ctx))
+
+	p := profile{Nickname: "jo", User: userDTO{Name: "john"}}
+	assert.NoError(t, vd.ValidateWith(p, vd.WithTags()))
+	verr := asValidationError(t, vd.ValidateWith(p, vd.Groups("update"), vd.WithTags()))
+	assert.Equal(t, "Nickname", verr.Path.String())
+
+	p.Nickname = "john"
+	verr = asValidationError(t, vd.ValidateWith(p, vd.Groups("update"), vd.WithTags()))
+	assert.Equal(t, "User.ID", verr.Path.String())
+	verr = asValidationError(t, vd.ValidateCtx(ctx, []profile{p}, vd.WithTags()))
+	assert.Equal(t, "[0].User.ID", verr.Path.String())
+
+	p.Nickname = ""
+	verr = asValidationError(t, vd.ValidateCtx(context.Background(), p, vd.Groups("admin"), vd.WithTags()))
+	assert.Equal(t, "Required", verr.Rule)
+	assert.NoError(t, vd.NewWalker(vd.Groups("create"), vd.WithTags()).Validate(p))
+}
@@ -0,0 +1,114 @@
+package validol
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+type Translator interface {
+	// Translate returns a human-readable message for the error, without its path.
+	Translate(err *ValidationError) string
+}
+
+// Catalog is a Translator with a message template per error code.
+// Templates refer to the params as {name} and to the value as {value}.
+// An explicit Message takes precedence over the template,
+// errors without a template fall back to their Error message without the path.
+type Catalog map[string]string
+
+var _ Translator = Catalog{}
+
+func (c Catalog) Translate(err *ValidationError) string {
+	tmpl, ok := c[err.Code]
+	if !ok || err.Code == "" || err.Message != "" {
+		return err.message()
+	}
+	return Interpolate(tmpl, err)
+}
+
+// Interpolate replaces {name} in tmpl with the param name of err, and {value} with its value.
+// Unknown placeholders are kept.
+func Interpolate(tmpl string, err *ValidationError) string {
+	var b strings.Builder
+	for {
+		start := strings.IndexByte(tmpl, '{')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(tmpl[start:], '}')
+		if end < 0 {
+			break
+		}
+		end += start
+		name := tmpl[start+1 : end]
+		b.WriteString(tmpl[:start])
+		if val, ok := err.Params[name]; ok {
+			b.WriteString(fmtParam(val))
+		} else if name == "value" {
+			b.WriteString(fmtParam(err.Value))
+		} else {
+			b.WriteString(tmpl[start : end+1])
+		}
+		tmpl = tmpl[end+1:]
+	}
+	b.WriteString(tmpl)
+	return b.String()
+}
+
+// fmtParam formats a param for humans, lists are joined with commas.
+func fmtParam(val any) string {
+	v := reflect.ValueOf(val)
+	if (v.Kind() == reflect.Slice || v.Kind() == reflect.Array) && v.Type().Elem().Kind() != reflect.Uint8 {
+		elems := make([]string, 0, v.Len())
+		for i := range v.Len() {
+			elems = append(elems, fmtParam(v.Index(i).Interface()))
+		}
+		return strings.Join(elems, ", ")
+	}
+	return fmt.Sprintf("%v", val)
+}
+
+var English = Catalog{
+	"required": "is required",
+	"empty":    "must be empty",
+	"nil":      "must be nil",
+	"not_nil":  "must not be nil",
+	"true":     "must be true",
+	"false":    "must be false",
+	"not":      "is not allowed",
+
+	"eq":     "must be equal to {val}",
+	"ne":     "must not be equal to {val}",
+	"gt":     "must be greater than {val}",
+	"gte":    "must be greater than or equal to {val}",
+	"lt":     "must be less than {val}",
+	"lte":    "must be less than or equal to {val}",
+	"one_of": "must be one of {vals}",
+
+	"len_eq":  "length must be {val}",
+	"len_ne":  "length must not be {val}",
+	"len_gt":  "length must be greater than {val}",
+	"len_gte": "length must be at least {val}",
+	"len_lt":  "length must be less than {val}",
+	"len_lte": "length must be at most {val}",
+
+	"starts_with":   "must start with {prefix}",
+	"ends_with":     "must end with {suffix}",
+	"contains":      "must contain {substr}",
+	"contains_rune": "must contain {rune}",
+	"email":         "must be a valid email address",
+	"uuid4":         "must be a valid UUID v4",
+
+	"eq_field":      "must be equal to {field}",
+	"ne_field":      "must not be equal to {field}",
+	"gt_field":      "must be greater than {field}",
+	"gte_field":     "must be greater than or equal to {field}",
+	"lt_field":      "must be less than {field}",
+	"lte_field":     "must be less than or equal to {field}",
+	"required_if":   "is required when {field} is {val}",
+	"required_with": "is required when {field} is present",
+
+	"immutable":           "must not change",
+	"allowed_transitions": "can not change from {old} to {value}",
+}
@@ -0,0 +1,71 @@
+package validol_test
+
+import (
+	"errors"
+	"testing"
+
+	vd "github.com/cospectrum/validol"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCodes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		err     error
+		code    string
+		message string
+	}{
+		{vd.Required(""), "required", "is required"},
+		{vd.Empty(1), "empty", "must be empty"},
+		{vd.Nil(&struct{}{}), "nil", "must be nil"},
+		{vd.NotNil[*int](nil), "not_nil", "must not be nil"},
+		{vd.True(false), "true", "must be true"},
+		{vd.False(true), "false", "must be false"},
+		{vd.Not(vd.Required[int])(1), "not", "is not allowed"},
+		{vd.Eq(1)(2), "eq", "must be equal to 1"},
+		{vd.Ne(1)(1), "ne", "must not be equal to 1"},
+		{vd.Gt(5)(1), "gt", "must be greater than 5"},
+		{vd.Gte(5)(1), "gte", "must be greater than or equal to 5"},
+		{vd.Lt(0.5)(1), "lt", "must be less than 0.5"},
+		{vd.Lte(0)(1), "lte", "must be less than or equal to 0"},
+		{vd.OneOf("a", "b")("c"), "one_of", "must be one of a, b"},
+		{vd.Len[string](vd.Eq(2))("abc"), "len_eq", "length must be 2"},
+		{vd.Len[[]int](vd.Gte(2))(nil), "len_gte", "length must be at least 2"},
+		{vd.Len[string](vd.Lte(2))("abc"), "len_lte", "length must be at most 2"},
+		{vd.StartsWith("x")("abc"), "starts_with", "must start with x"},
+		{vd.EndsWith("x")("abc"), "ends_with", "must end with x"},
+		{vd.Contains("x")("abc"), "contains", "must contain x"},
+		{vd.ContainsRune('x')("abc"), "contains_rune", "must contain x"},
+		{vd.Email("abc"), "email", "must be a valid email address"},
+		{vd.UUID4("abc"), "uuid4", "must be a valid UUID v4"},
+		{vd.Validate(period{2, 1}), "gt_field", "must be greater than Start"},
+		{vd.Validate(contact{Method: "sms"}), "required_if", "is required when Method is sms"},
+		{vd.AllowedTransitions(map[int][]int{})(1, 2), "allowed_transitions", "can not change from 1 to 2"},
+	}
+	for _, tc := range tests {
+		verr := asValidationError(t, tc.err)
+		assert.Equal(t, tc.code, verr.Code)
+		assert.Equal(t, tc.message, vd.English.Translate(verr))
+	}
+
+	verr := asValidationError(t, vd.Len[string](vd.Gte(2))("a"))
+	assert.Equal(t, "Gte", verr.Rule)
+	assert.Equal(t, map[string]any{"val": 2}, verr.Params)
+}
+
+func TestCatalog(t *testing.T) {
+	t.Parallel()
+
+	catalog := vd.Catalog{
+		"gt":      "{value} ist nicht größer als {val}",
+		"len_gte": "mindestens {val} {unknown}",
+	}
+	assert.Equal(t, "3 ist nicht größer als 5", catalog.Translate(asValidationError(t, vd.Gt(5)(3))))
+	assert.Equal(t, "mindestens 2 {unknown}", catalog.Translate(asValidationError(t, vd.Len[string](vd.Gte(2))(""))))
+
+	verr := asValidationError(t, vd.Walk(struct{ Qty qty }{}))
+	assert.Equal(t, "validol.Gt(0)(0) failed", vd.Catalog{}.Translate(verr))
+	assert.Equal(t, "must be greater than 0", vd.English.Translate(verr))
+	assert.Equal(t, "failing", vd.English.Translate(&vd.ValidationError{Err: errors.New("failing")}))
+}
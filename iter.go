@@ -0,0 +1,33 @@
+//go:build go1.23
+
+package validol
+
+import "iter"
+
+func EachSeq[E any](validate Validator[E], opts ...Option) Validator[iter.Seq[E]] {
+	cfg := newConfig(opts)
+	return func(seq iter.Seq[E]) error {
+		errs := &collector{all: cfg.collectAll}
+		i := 0
+		for e := range seq {
+			if errs.add(validate(e), indexElem(i)) {
+				break
+			}
+			i++
+		}
+		return errs.err()
+	}
+}
+
+func EachSeq2[K, V any](validate Validator[V], opts ...Option) Validator[iter.Seq2[K, V]] {
+	cfg := newConfig(opts)
+	return func(seq iter.Seq2[K, V]) error {
+		errs := &collector{all: cfg.collectAll}
+		for k, v := range seq {
+			if errs.add(validate(v), mapValueElem(k)) {
+				break
+			}
+		}
+		return errs.err()
+	}
+}
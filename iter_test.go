@@ -0,0 +1,41 @@
+//go:build go1.23
+
+package validol_test
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	vd "github.com/cospectrum/validol"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEachSeq(t *testing.T) {
+	t.Parallel()
+
+	each := vd.EachSeq(vd.Gt(0))
+	assert.NoError(t, each(slices.Values([]int{1, 2})))
+
+	verr := asValidationError(t, each(slices.Values([]int{1, 0, -1})))
+	assert.Equal(t, "[1]", verr.Path.String())
+
+	errs := vd.Errors(vd.EachSeq(vd.Gt(0), vd.WithCollectAll())(slices.Values([]int{1, 0, -1})))
+	assert.Len(t, errs, 2)
+	assert.Equal(t, "[2]", errs[1].Path.String())
+}
+
+func TestEachSeq2(t *testing.T) {
+	t.Parallel()
+
+	each := vd.EachSeq2[string](vd.Gt(0))
+	assert.NoError(t, each(maps.All(map[string]int{"a": 1})))
+
+	verr := asValidationError(t, each(maps.All(map[string]int{"a": 0})))
+	assert.Equal(t, `["a"]`, verr.Path.String())
+
+	errs := vd.Errors(vd.EachSeq2[int](vd.Gt(0), vd.WithCollectAll())(slices.All([]int{0, 1, -1})))
+	assert.Len(t, errs, 2)
+	assert.Equal(t, "[0]", errs[0].Path.String())
+	assert.Equal(t, "[2]", errs[1].Path.String())
+}
@@ -0,0 +1,16 @@
+package locale_test
+
+import (
+	"testing"
+
+	"github.com/cospectrum/validol/locale"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAcceptLanguage(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"fr-CH", "fr", "en", "de"}, locale.ParseAcceptLanguage("fr-CH, fr;q=0.9, en;q=0.8, de;q=0.7, *;q=0.5"))
+	assert.Equal(t, []string{"de", "en"}, locale.ParseAcceptLanguage("en;q=0.5,de , ru;q=0, es;q=abc"))
+	assert.Empty(t, locale.ParseAcceptLanguage(""))
+}
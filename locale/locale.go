@@ -0,0 +1,254 @@
+// Package locale renders validol errors with message catalogs loaded from files.
+package locale
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+
+	vd "github.com/cospectrum/validol"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed catalogs/*.json
+var builtin embed.FS
+
+// Catalog holds the message templates of a language, keyed by error code.
+// It implements validol.Translator, errors with an explicit Message or without
+// a template fall back to their Error message.
+type Catalog struct {
+	lang     string
+	plural   PluralRule
+	messages map[string]message
+}
+
+var _ vd.Translator = &Catalog{}
+
+// message is a template, or a set of templates per plural form
+// chosen by the param with the name count.
+type message struct {
+	count string
+	forms map[string]string
+}
+
+func (c *Catalog) Lang() string {
+	return c.lang
+}
+
+func (c *Catalog) Translate(err *vd.ValidationError) string {
+	if tmpl, ok := c.lookup(err); ok {
+		return vd.Interpolate(tmpl, err)
+	}
+	return vd.Catalog{}.Translate(err)
+}
+
+func (c *Catalog) lookup(err *vd.ValidationError) (string, bool) {
+	msg, ok := c.messages[err.Code]
+	if !ok || err.Code == "" || err.Message != "" {
+		return "", false
+	}
+	if msg.count == "" {
+		return msg.forms[Other], true
+	}
+	if tmpl, ok := msg.forms[c.plural(err.Params[msg.count])]; ok {
+		return tmpl, true
+	}
+	tmpl, ok := msg.forms[Other]
+	return tmpl, ok
+}
+
+// ParseJSON parses a catalog of the form
+//
+//	{"required": "is required", "len_gte": {"plural": "val", "one": "...", "other": "..."}}
+//
+// or a gotext messages file with the "language" and "messages" keys.
+func ParseJSON(lang string, data []byte) (*Catalog, error) {
+	var gotext struct {
+		Language string `json:"language"`
+		Messages []struct {
+			ID          string `json:"id"`
+			Translation string `json:"translation"`
+		} `json:"messages"`
+	}
+	if err := json.Unmarshal(data, &gotext); err == nil && gotext.Messages != nil {
+		raw := make(map[string]any, len(gotext.Messages))
+		for _, msg := range gotext.Messages {
+			raw[msg.ID] = msg.Translation
+		}
+		return newCatalog(lang, raw)
+	}
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("locale: %s: %w", lang, err)
+	}
+	return newCatalog(lang, raw)
+}
+
+// ParseYAML parses a catalog with the same structure as in ParseJSON.
+func ParseYAML(lang string, data []byte) (*Catalog, error) {
+	var raw map[string]any
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("locale: %s: %w", lang, err)
+	}
+	return newCatalog(lang, raw)
+}
+
+func newCatalog(lang string, raw map[string]any) (*Catalog, error) {
+	lang = normalize(lang)
+	c := &Catalog{
+		lang:     lang,
+		plural:   pluralRuleOf(lang),
+		messages: make(map[string]message, len(raw)),
+	}
+	for code, val := range raw {
+		msg, err := parseMessage(val)
+		if err != nil {
+			return nil, fmt.Errorf("locale: %s: %q: %w", lang, code, err)
+		}
+		c.messages[code] = msg
+	}
+	return c, nil
+}
+
+func parseMessage(val any) (message, error) {
+	switch val := val.(type) {
+	case string:
+		return message{forms: map[string]string{Other: val}}, nil
+	case map[string]any:
+		msg := message{forms: make(map[string]string, len(val))}
+		for key, form := range val {
+			s, ok := form.(string)
+			if !ok {
+				return msg, fmt.Errorf("%q is not a string", key)
+			}
+			if key == "plural" {
+				msg.count = s
+				continue
+			}
+			if !isPluralForm(key) {
+				return msg, fmt.Errorf("unknown plural form %q", key)
+			}
+			msg.forms[key] = s
+		}
+		if msg.forms[Other] == "" {
+			return msg, errors.New(`missing "other" form`)
+		}
+		return msg, nil
+	default:
+		return message{}, fmt.Errorf("unexpected %T", val)
+	}
+}
+
+// Bundle is a set of catalogs with a fallback language.
+type Bundle struct {
+	fallback string
+	catalogs map[string]*Catalog
+}
+
+func NewBundle(fallback string) *Bundle {
+	return &Bundle{
+		fallback: normalize(fallback),
+		catalogs: make(map[string]*Catalog),
+	}
+}
+
+// Default returns a new bundle with the built-in en, ru and de catalogs,
+// en is the fallback.
+func Default() *Bundle {
+	b := NewBundle("en")
+	if err := b.LoadFS(builtin, "catalogs"); err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// Add adds c to the bundle, replacing the catalog of the same language.
+func (b *Bundle) Add(c *Catalog) {
+	b.catalogs[c.lang] = c
+}
+
+// LoadFS loads the *.json, *.yaml and *.yml catalogs of dir,
+// the language is the file name without the extension, e.g. pt-BR.json.
+func (b *Bundle) LoadFS(fsys fs.FS, dir string) error {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return fmt.Errorf("locale: %w", err)
+	}
+	for _, entry := range entries {
+		ext := path.Ext(entry.Name())
+		var parse func(string, []byte) (*Catalog, error)
+		switch ext {
+		case ".json":
+			parse = ParseJSON
+		case ".yaml", ".yml":
+			parse = ParseYAML
+		default:
+			continue
+		}
+		if entry.IsDir() {
+			continue
+		}
+		data, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return fmt.Errorf("locale: %w", err)
+		}
+		c, err := parse(strings.TrimSuffix(entry.Name(), ext), data)
+		if err != nil {
+			return err
+		}
+		b.Add(c)
+	}
+	return nil
+}
+
+// Catalog returns the catalog of lang or of its base language, e.g. de for de-AT,
+// or nil if there is none.
+func (b *Bundle) Catalog(lang string) *Catalog {
+	lang = normalize(lang)
+	if c, ok := b.catalogs[lang]; ok {
+		return c
+	}
+	base, _, _ := strings.Cut(lang, "-")
+	return b.catalogs[base]
+}
+
+// Translator returns a translator for the first of langs with a catalog.
+// Messages missing in the catalog are taken from the fallback language.
+func (b *Bundle) Translator(langs ...string) vd.Translator {
+	var out translator
+	for _, lang := range langs {
+		if c := b.Catalog(lang); c != nil {
+			out = append(out, c)
+			break
+		}
+	}
+	if c := b.Catalog(b.fallback); c != nil && (len(out) == 0 || out[0] != c) {
+		out = append(out, c)
+	}
+	return out
+}
+
+// Match returns a translator for the languages of an Accept-Language header.
+func (b *Bundle) Match(acceptLanguage string) vd.Translator {
+	return b.Translator(ParseAcceptLanguage(acceptLanguage)...)
+}
+
+// translator tries the catalogs in order.
+type translator []*Catalog
+
+func (t translator) Translate(err *vd.ValidationError) string {
+	for _, c := range t {
+		if tmpl, ok := c.lookup(err); ok {
+			return vd.Interpolate(tmpl, err)
+		}
+	}
+	return vd.Catalog{}.Translate(err)
+}
+
+func normalize(lang string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(lang), "_", "-"))
+}
@@ -0,0 +1,162 @@
+package locale_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"testing/fstest"
+
+	vd "github.com/cospectrum/validol"
+	"github.com/cospectrum/validol/locale"
+	"github.com/stretchr/testify/assert"
+)
+
+func asValidationError(t *testing.T, err error) *vd.ValidationError {
+	t.Helper()
+	verr, ok := err.(*vd.ValidationError) //nolint:errorlint // the error is not wrapped
+	if !ok {
+		t.Fatalf("expected *ValidationError, got %v", err)
+	}
+	return verr
+}
+
+func TestDefault(t *testing.T) {
+	t.Parallel()
+
+	bundle := locale.Default()
+	for _, lang := range []string{"en", "ru", "de"} {
+		c := bundle.Catalog(lang)
+		if assert.NotNil(t, c, lang) {
+			assert.Equal(t, lang, c.Lang())
+			for code := range vd.English {
+				// without a template the message falls back to the cause
+				verr := &vd.ValidationError{Code: code, Err: errFailing}
+				assert.NotEqual(t, "failing", c.Translate(verr), lang+": "+code)
+			}
+		}
+	}
+
+	gt := asValidationError(t, vd.Gt(5)(1))
+	assert.Equal(t, "must be greater than 5", bundle.Catalog("en").Translate(gt))
+	assert.Equal(t, "должно быть больше 5", bundle.Catalog("ru").Translate(gt))
+	assert.Equal(t, "muss größer als 5 sein", bundle.Catalog("de-AT").Translate(gt))
+	assert.Nil(t, bundle.Catalog("fr"))
+
+	for code := range vd.English {
+		verr := &vd.ValidationError{Code: code}
+		assert.Equal(t, vd.English.Translate(verr), bundle.Catalog("en").Translate(verr))
+	}
+}
+
+func TestBuiltinCatalogs(t *testing.T) {
+	t.Parallel()
+
+	codes := keys(vd.English)
+	for _, lang := range []string{"en", "ru", "de"} {
+		data, err := os.ReadFile(filepath.Join("catalogs", lang+".json"))
+		assert.NoError(t, err)
+		var raw map[string]any
+		assert.NoError(t, json.Unmarshal(data, &raw))
+		assert.Equal(t, codes, keys(raw), lang)
+	}
+
+	// en.json is a copy of validol.English
+	data, err := os.ReadFile(filepath.Join("catalogs", "en.json"))
+	assert.NoError(t, err)
+	var en vd.Catalog
+	assert.NoError(t, json.Unmarshal(data, &en))
+	assert.Equal(t, vd.English, en)
+}
+
+func keys[V any](m map[string]V) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func TestBundleTranslator(t *testing.T) {
+	t.Parallel()
+
+	bundle := locale.Default()
+	partial, err := locale.ParseJSON("fr", []byte(`{"gt": "doit être supérieur à {val}"}`))
+	assert.NoError(t, err)
+	bundle.Add(partial)
+
+	gt := asValidationError(t, vd.Gt(5)(1))
+	email := asValidationError(t, vd.Email("x"))
+
+	fr := bundle.Match("fr-CH, fr;q=0.9, en;q=0.8, *;q=0.5")
+	assert.Equal(t, "doit être supérieur à 5", fr.Translate(gt))
+	assert.Equal(t, "must be a valid email address", fr.Translate(email))
+
+	assert.Equal(t, "должно быть больше 5", bundle.Match("es, ru;q=0.5").Translate(gt))
+	assert.Equal(t, "must be greater than 5", bundle.Match("").Translate(gt))
+	assert.Equal(t, "must be greater than 5", bundle.Translator("ja").Translate(gt))
+	assert.Equal(t, "failing", bundle.Translator("ru").Translate(&vd.ValidationError{Code: "unknown", Err: errFailing}))
+}
+
+type failure string
+
+func (f failure) Error() string { return string(f) }
+
+const errFailing = failure("failing")
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		"messages/ru.yaml": {Data: []byte(`
+len_gte:
+  plural: val
+  one: "минимум {val} элемент"
+  few: "минимум {val} элемента"
+  other: "минимум {val} элементов"
+`)},
+		"messages/en-US.json": {Data: []byte(`{"len_gte": {"plural": "val", "one": "at least {val} item", "other": "at least {val} items"}}`)},
+		"messages/de.json":    {Data: mustGotext(t)},
+		"messages/README.md":  {Data: []byte("ignored")},
+	}
+	bundle := locale.NewBundle("en")
+	assert.NoError(t, bundle.LoadFS(fsys, "messages"))
+
+	minLen := func(n int) *vd.ValidationError {
+		return asValidationError(t, vd.Len[[]int](vd.Gte(n))(nil))
+	}
+	ru := bundle.Translator("ru")
+	assert.Equal(t, "минимум 1 элемент", ru.Translate(minLen(1)))
+	assert.Equal(t, "минимум 3 элемента", ru.Translate(minLen(3)))
+	assert.Equal(t, "минимум 5 элементов", ru.Translate(minLen(5)))
+	assert.Equal(t, "минимум 21 элемент", ru.Translate(minLen(21)))
+
+	en := bundle.Translator("en-us")
+	assert.Equal(t, "at least 1 item", en.Translate(minLen(1)))
+	assert.Equal(t, "at least 2 items", en.Translate(minLen(2)))
+
+	assert.Equal(t, "ist erforderlich", bundle.Translator("de").Translate(asValidationError(t, vd.Required(""))))
+
+	for _, data := range []string{`[]`, `{"gt": 1}`, `{"gt": {"one": "x"}}`, `{"gt": {"some": "x", "other": "y"}}`} {
+		_, err := locale.ParseJSON("en", []byte(data))
+		assert.Error(t, err, data)
+	}
+	_, err := locale.ParseYAML("en", []byte("gt: [1"))
+	assert.Error(t, err)
+	assert.Error(t, bundle.LoadFS(fsys, "missing"))
+	assert.Error(t, bundle.LoadFS(fstest.MapFS{"bad.json": {Data: []byte("{")}}, "."))
+}
+
+func mustGotext(t *testing.T) []byte {
+	t.Helper()
+	data, err := json.Marshal(map[string]any{
+		"language": "de",
+		"messages": []map[string]string{
+			{"id": "required", "message": "is required", "translation": "ist erforderlich"},
+		},
+	})
+	assert.NoError(t, err)
+	return data
+}
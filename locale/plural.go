@@ -0,0 +1,90 @@
+package locale
+
+import (
+	"math"
+	"reflect"
+	"strings"
+)
+
+// Plural forms of the CLDR plural rules.
+const (
+	Zero  = "zero"
+	One   = "one"
+	Two   = "two"
+	Few   = "few"
+	Many  = "many"
+	Other = "other"
+)
+
+// PluralRule returns the plural form for a count, which may be of any numeric type.
+type PluralRule func(count any) string
+
+// PluralRules are the plural rules by base language, the others use English.
+var PluralRules = map[string]PluralRule{
+	"en": englishPlural,
+	"de": englishPlural,
+	"ru": slavicPlural,
+	"uk": slavicPlural,
+}
+
+func pluralRuleOf(lang string) PluralRule {
+	base, _, _ := strings.Cut(lang, "-")
+	if rule, ok := PluralRules[base]; ok {
+		return rule
+	}
+	return englishPlural
+}
+
+func isPluralForm(form string) bool {
+	switch form {
+	case Zero, One, Two, Few, Many, Other:
+		return true
+	default:
+		return false
+	}
+}
+
+func englishPlural(count any) string {
+	if n, ok := integer(count); ok && n == 1 {
+		return One
+	}
+	return Other
+}
+
+func slavicPlural(count any) string {
+	n, ok := integer(count)
+	if !ok {
+		return Other
+	}
+	switch n %= 100; {
+	case n%10 == 1 && n != 11:
+		return One
+	case n%10 >= 2 && n%10 <= 4 && (n < 12 || n > 14):
+		return Few
+	default:
+		return Many
+	}
+}
+
+// integer returns the absolute value of an integral count.
+func integer(count any) (uint64, bool) {
+	v := reflect.ValueOf(count)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n := v.Int()
+		if n < 0 {
+			return uint64(-n), true
+		}
+		return uint64(n), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint(), true
+	case reflect.Float32, reflect.Float64:
+		f := math.Abs(v.Float())
+		if f != math.Trunc(f) || f > math.MaxUint64 {
+			return 0, false
+		}
+		return uint64(f), true
+	default:
+		return 0, false
+	}
+}
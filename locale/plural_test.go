@@ -0,0 +1,25 @@
+package locale_test
+
+import (
+	"testing"
+
+	"github.com/cospectrum/validol/locale"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPluralRules(t *testing.T) {
+	t.Parallel()
+
+	en, ru := locale.PluralRules["en"], locale.PluralRules["ru"]
+	assert.Equal(t, locale.One, en(1))
+	assert.Equal(t, locale.One, en(uint8(1)))
+	assert.Equal(t, locale.Other, en(2))
+	assert.Equal(t, locale.Other, en(1.5))
+	assert.Equal(t, locale.Other, en("1"))
+
+	for n, form := range map[int]string{1: locale.One, 21: locale.One, 11: locale.Many, 2: locale.Few, 24: locale.Few, 12: locale.Many, 5: locale.Many, 0: locale.Many, -3: locale.Few} {
+		assert.Equal(t, form, ru(n), n)
+	}
+	assert.Equal(t, locale.One, ru(101.0))
+	assert.Equal(t, locale.Other, ru(1.5))
+}
@@ -0,0 +1,59 @@
+package validol
+
+import "reflect"
+
+type Option func(*config)
+
+type config struct {
+	collectAll  bool
+	concurrency int
+	maxDepth    int
+	tagName     string
+	skipTypes   []reflect.Type
+	groups      []string
+	partial     fieldMask
+}
+
+func newConfig(opts []Option) config {
+	var cfg config
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+func WithCollectAll() Option {
+	return func(c *config) {
+		c.collectAll = true
+	}
+}
+
+func WithConcurrency(n int) Option {
+	return func(c *config) {
+		c.concurrency = n
+	}
+}
+
+func WithMaxDepth(n int) Option {
+	return func(c *config) {
+		c.maxDepth = n
+	}
+}
+
+// WithTags enables the rules of the `validate` struct tags, they are ignored by default.
+func WithTags() Option {
+	return WithTagName(defaultTagName)
+}
+
+// WithTagName enables the rules of the name struct tags, "" disables them.
+func WithTagName(name string) Option {
+	return func(c *config) {
+		c.tagName = name
+	}
+}
+
+func SkipTypes(types ...reflect.Type) Option {
+	return func(c *config) {
+		c.skipTypes = append(c.skipTypes, types...)
+	}
+}
@@ -0,0 +1,54 @@
+package validol
+
+import "fmt"
+
+// WithMessage replaces the message of the error of validate,
+// the original error is kept as Err.
+func WithMessage[T any](validate Validator[T], msg string) Validator[T] {
+	return override(validate, func(e *ValidationError) {
+		e.Message = msg
+	})
+}
+
+func WithMessagef[T any](validate Validator[T], format string, args ...any) Validator[T] {
+	return WithMessage(validate, fmt.Sprintf(format, args...))
+}
+
+// WithCode replaces the code of the error of validate,
+// the original error is kept as Err.
+func WithCode[T any](validate Validator[T], code string) Validator[T] {
+	return override(validate, func(e *ValidationError) {
+		e.Code = code
+	})
+}
+
+// WithErrorFunc replaces the error of validate with the result of fn.
+func WithErrorFunc[T any](validate Validator[T], fn func(T, error) error) Validator[T] {
+	return func(t T) error {
+		if err := validate(t); err != nil {
+			return fn(t, err)
+		}
+		return nil
+	}
+}
+
+// override wraps the error of validate into a *ValidationError modified by set.
+// A single validation error is copied with its rule, params and path,
+// other errors are attributed to t.
+func override[T any](validate Validator[T], set func(*ValidationError)) Validator[T] {
+	return func(t T) error {
+		err := validate(t)
+		if err == nil {
+			return nil
+		}
+		var out ValidationError
+		if verr, ok := err.(*ValidationError); ok { //nolint:errorlint // only direct errors are copied
+			out = *verr
+		} else {
+			out = ValidationError{Value: t}
+		}
+		out.Err = err
+		set(&out)
+		return &out
+	}
+}
@@ -0,0 +1,82 @@
+package validol_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	vd "github.com/cospectrum/validol"
+	"github.com/stretchr/testify/assert"
+)
+
+var corpEmail = vd.All(vd.Email, vd.EndsWith("@corp.com"))
+
+func TestWithMessage(t *testing.T) {
+	t.Parallel()
+
+	validate := vd.WithMessage(corpEmail, "must be a company email")
+	assert.NoError(t, validate("john@corp.com"))
+
+	err := validate("john@mail.com")
+	verr := asValidationError(t, err)
+	assert.Equal(t, "must be a company email", err.Error())
+	assert.Equal(t, "must be a company email", verr.Message)
+	assert.Equal(t, "EndsWith", verr.Rule)
+	assert.Equal(t, "ends_with", verr.Code)
+	assert.Equal(t, "john@mail.com", verr.Value)
+	assert.Equal(t, "must be a company email", vd.English.Translate(verr))
+	inner := asValidationError(t, errors.Unwrap(err))
+	assert.Equal(t, `validol.EndsWith("@corp.com")("john@mail.com") failed`, inner.Error())
+
+	notAdmin := vd.WithMessagef(vd.Not(vd.Eq("admin")), "%q is reserved", "admin")
+	assert.Equal(t, `"admin" is reserved`, notAdmin("admin").Error())
+
+	field := vd.Field("Email", func(a account) string { return a.Email }, validate)
+	assert.Equal(t, "Email: must be a company email", field(account{}).Error())
+	wrapped := vd.WithMessage(vd.Field("Email", func(a account) string { return a.Email }, vd.Email), "invalid email")
+	err = wrapped(account{})
+	assert.Equal(t, "Email: invalid email", err.Error())
+	assert.Equal(t, "Email", asValidationError(t, err).Path.String())
+
+	joined := vd.WithMessage(vd.Struct(
+		vd.Field("Email", func(a account) string { return a.Email }, vd.Email),
+		vd.Field("Age", func(a account) int { return a.Age }, vd.Gte(18)),
+	), "invalid account")
+	err = joined(account{})
+	verr = asValidationError(t, err)
+	assert.Equal(t, "invalid account", err.Error())
+	assert.Equal(t, account{}, verr.Value)
+	assert.Len(t, vd.Errors(errors.Unwrap(err)), 2)
+}
+
+func TestWithCode(t *testing.T) {
+	t.Parallel()
+
+	validate := vd.WithCode(corpEmail, "corp_email")
+	err := validate("john@mail.com")
+	verr := asValidationError(t, err)
+	assert.Equal(t, "corp_email", verr.Code)
+	assert.Equal(t, "EndsWith", verr.Rule)
+	assert.Equal(t, `validol.EndsWith("@corp.com")("john@mail.com") failed`, err.Error())
+	assert.Equal(t, "must be a company email", vd.Catalog{"corp_email": "must be a company email"}.Translate(verr))
+
+	foreign := vd.WithCode(func(int) error { return errFailing }, "custom")
+	err = foreign(1)
+	assert.ErrorIs(t, err, errFailing)
+	assert.Equal(t, "failing", err.Error())
+	verr = asValidationError(t, vd.Walk(struct{ F []failing }{F: []failing{{}}}))
+	coded := vd.WithCode(func(struct{}) error { return verr }, "custom")
+	assert.Equal(t, "F[0]: failing", coded(struct{}{}).Error())
+}
+
+func TestWithErrorFunc(t *testing.T) {
+	t.Parallel()
+
+	validate := vd.WithErrorFunc(vd.Gt(0), func(n int, err error) error {
+		return fmt.Errorf("quantity %d: %w", n, err)
+	})
+	assert.NoError(t, validate(1))
+	err := validate(0)
+	assert.Equal(t, "quantity 0: validol.Gt(0)(0) failed", err.Error())
+	assert.Equal(t, "Gt", asValidationError(t, errors.Unwrap(err)).Rule)
+}
@@ -0,0 +1,69 @@
+package validol
+
+import (
+	"context"
+	"sync"
+)
+
+func Parallel[T any](validators ...Validator[T]) Validator[T] {
+	return func(t T) error {
+		errs := make([]error, len(validators))
+		var wg sync.WaitGroup
+		for i, f := range validators {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs[i] = f(t)
+			}()
+		}
+		wg.Wait()
+		for _, err := range errs {
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func ParallelCtx[T any](validators ...ValidatorCtx[T]) ValidatorCtx[T] {
+	return func(ctx context.Context, t T) error {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		errs := make([]error, len(validators))
+		canceled := make([]bool, len(validators))
+		var wg sync.WaitGroup
+		for i, f := range validators {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs[i] = f(ctx, t)
+				canceled[i] = ctx.Err() != nil
+				if errs[i] != nil {
+					cancel()
+				}
+			}()
+		}
+		wg.Wait()
+		return firstFailure(errs, canceled)
+	}
+}
+
+// firstFailure returns the first error in order, skipping the errors of validators
+// that returned after the context was done, e.g. canceled by a failed sibling.
+func firstFailure(errs []error, canceled []bool) error {
+	var late error
+	for i, err := range errs {
+		switch {
+		case err == nil:
+		case canceled[i]:
+			if late == nil {
+				late = err
+			}
+		default:
+			return err
+		}
+	}
+	return late
+}
@@ -0,0 +1,120 @@
+package validol_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	vd "github.com/cospectrum/validol"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParallel(t *testing.T) {
+	t.Parallel()
+
+	v := vd.Parallel(vd.Gt(0), vd.Lt(10), vd.Ne(5))
+	assert.NoError(t, v(1))
+
+	verr := asValidationError(t, v(0))
+	assert.Equal(t, "Gt", verr.Rule)
+	verr = asValidationError(t, v(5))
+	assert.Equal(t, "Ne", verr.Rule)
+	verr = asValidationError(t, vd.Parallel(vd.Lt(-1), vd.Gt(0))(-1))
+	assert.Equal(t, "Lt", verr.Rule)
+
+	assert.NoError(t, vd.Parallel[int]()(0))
+}
+
+func TestParallelCtx(t *testing.T) {
+	t.Parallel()
+
+	slow := func(ctx context.Context, _ int) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(10 * time.Second):
+			return errors.New("timeout")
+		}
+	}
+	start := time.Now()
+	verr := asValidationError(t, vd.ParallelCtx(slow, vd.Lift(vd.Gt(0)), slow)(context.Background(), 0))
+	assert.Equal(t, "Gt", verr.Rule)
+	assert.Less(t, time.Since(start), 5*time.Second)
+
+	assert.NoError(t, vd.ParallelCtx(vd.Lift(vd.Gt(0)), vd.Lift(vd.Lt(2)))(context.Background(), 1))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, vd.ParallelCtx(slow)(ctx, 0), context.Canceled)
+}
+
+type counted struct {
+	N     int
+	calls *atomic.Int64
+}
+
+func (c counted) Validate() error {
+	c.calls.Add(1)
+	return vd.Ne(0)(c.N)
+}
+
+func TestWalkConcurrency(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int64
+	in := make([]map[string][]counted, 0)
+	for i := range 50 {
+		m := make(map[string][]counted)
+		for j := range 20 {
+			m[fmt.Sprint(j)] = []counted{{N: i*j + 1, calls: &calls}, {N: 1, calls: &calls}}
+		}
+		in = append(in, m)
+	}
+	assert.NoError(t, vd.WalkWith(in, vd.WithConcurrency(8)))
+	assert.Equal(t, int64(50*20*2), calls.Load())
+
+	in[7]["3"][1].N = 0
+	in[30]["1"][0].N = 0
+	in[30]["0"][1].N = 0
+
+	seq := vd.Walk(in)
+	for _, n := range []int{2, 4, 16} {
+		err := vd.WalkWith(in, vd.WithConcurrency(n))
+		assert.Equal(t, seq.Error(), err.Error())
+		assert.Equal(t, `[7]["3"][1]: validol.Ne(0)(0) failed`, err.Error())
+	}
+
+	seqAll := vd.WalkWith(in, vd.WithCollectAll())
+	assert.Len(t, vd.Errors(seqAll), 3)
+	for _, n := range []int{2, 4, 16} {
+		err := vd.WalkWith(in, vd.WithCollectAll(), vd.WithConcurrency(n))
+		assert.Equal(t, seqAll.Error(), err.Error())
+	}
+}
+
+type slowCheck int
+
+func (s slowCheck) ValidateCtx(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(time.Duration(s) * time.Millisecond):
+		return nil
+	}
+}
+
+func TestWalkConcurrencyCancel(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	in := []slowCheck{10_000, 10_000, 10_000}
+	start := time.Now()
+	err := vd.WalkCtx(ctx, in, vd.WithConcurrency(4))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
@@ -0,0 +1,132 @@
+package validol
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Partial validates only the provided fields, given as dot-separated paths
+// of Go field names or json names, e.g. "Address.City" or "address.city".
+// A provided field is validated with all of its descendants.
+func Partial(paths ...string) Option {
+	return func(c *config) {
+		if c.partial == nil {
+			c.partial = fieldMask{}
+		}
+		for _, path := range paths {
+			c.partial.add(strings.Split(path, "."))
+		}
+	}
+}
+
+// JSONPaths returns the paths of the keys present in a JSON object,
+// to be used with Partial. Arrays are not descended into, an empty object is a path itself.
+func JSONPaths(data []byte) ([]string, error) {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, fmt.Errorf("validol: %w", err)
+	}
+	if obj == nil {
+		return nil, errors.New("validol: expected a JSON object")
+	}
+	var paths []string
+	if err := collectJSONPaths(obj, "", &paths); err != nil {
+		return nil, err
+	}
+	slices.Sort(paths)
+	return paths, nil
+}
+
+func collectJSONPaths(obj map[string]json.RawMessage, prefix string, paths *[]string) error {
+	for key, raw := range obj {
+		var nested map[string]json.RawMessage
+		if len(raw) == 0 || raw[0] != '{' {
+			*paths = append(*paths, prefix+key)
+			continue
+		}
+		if err := json.Unmarshal(raw, &nested); err != nil {
+			return fmt.Errorf("validol: %w", err)
+		}
+		if len(nested) == 0 {
+			// the object is provided as a whole
+			*paths = append(*paths, prefix+key)
+			continue
+		}
+		if err := collectJSONPaths(nested, prefix+key+".", paths); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fieldMask is a tree of the provided paths.
+// A nil mask provides everything.
+type fieldMask map[string]fieldMask
+
+func (m fieldMask) add(path []string) {
+	sub, ok := m[path[0]]
+	switch {
+	case len(path) == 1:
+		m[path[0]] = nil
+	case ok && sub == nil:
+		// already provided as a whole
+	default:
+		if !ok {
+			sub = fieldMask{}
+			m[path[0]] = sub
+		}
+		sub.add(path[1:])
+	}
+}
+
+// field returns the mask of a struct field and reports whether it is provided.
+// The keys match as in encoding/json: exactly, then case-insensitively.
+// Embedded structs without a json name are flattened.
+func (m fieldMask) field(fp fieldPlan) (fieldMask, bool) {
+	if m == nil {
+		return nil, true
+	}
+	if sub, ok := m[fp.name]; ok {
+		return sub, true
+	}
+	key := fp.name
+	if fp.jsonName != "" {
+		if sub, ok := m[fp.jsonName]; ok {
+			return sub, true
+		}
+		key = fp.jsonName
+	} else if fp.embedded {
+		return m, true
+	}
+	for name, sub := range m {
+		if strings.EqualFold(name, key) {
+			return sub, true
+		}
+	}
+	return nil, false
+}
+
+// entry returns the mask of a map entry and reports whether it is provided.
+func (m fieldMask) entry(key reflect.Value) (fieldMask, bool) {
+	if m == nil {
+		return nil, true
+	}
+	sub, ok := m[fmt.Sprint(keyOf(key))]
+	return sub, ok
+}
+
+// jsonName returns the name of the field in encoding/json,
+// or an empty string if the tag does not rename it.
+// The tag `json:"-,"` names the field "-".
+func jsonName(sf reflect.StructField) string {
+	tag := sf.Tag.Get("json")
+	if tag == "-" {
+		return ""
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	return name
+}
@@ -0,0 +1,111 @@
+package validol_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	vd "github.com/cospectrum/validol"
+	"github.com/stretchr/testify/assert"
+)
+
+type patchAddress struct {
+	City string `json:"city"`
+	Zip  string `json:"zip"  validate:"len=5"`
+}
+
+func (a patchAddress) Validate() error {
+	return vd.All(
+		vd.Field("City", func(a patchAddress) string { return a.City }, vd.Required[string]),
+		func(a patchAddress) error { return vd.WalkWith(a, vd.WithTags()) },
+	)(a)
+}
+
+type PatchBase struct {
+	Name string `json:"name" validate:"required,max_len=10"`
+}
+
+type patchUser struct {
+	PatchBase
+	Email   email          `json:"email"   validate:"required"`
+	Age     int            `json:"age"     validate:"gte=18"`
+	Address patchAddress   `json:"address"`
+	Tags    map[string]qty `json:"tags"`
+}
+
+func TestPartial(t *testing.T) {
+	t.Parallel()
+
+	assert.Error(t, vd.ValidateWith(patchUser{}, vd.WithTags()))
+	assert.NoError(t, vd.ValidateWith(patchUser{}, vd.Partial(), vd.WithTags()))
+
+	verr := asValidationError(t, vd.ValidateWith(patchUser{Age: 10}, vd.Partial("age"), vd.WithTags()))
+	assert.Equal(t, "Age", verr.Path.String())
+	assert.NoError(t, vd.ValidateWith(patchUser{Age: 20}, vd.Partial("Age"), vd.WithTags()))
+
+	verr = asValidationError(t, vd.ValidateWith(patchUser{}, vd.Partial("name"), vd.WithTags()))
+	assert.Equal(t, "PatchBase.Name", verr.Path.String())
+	assert.Equal(t, "Required", verr.Rule)
+	verr = asValidationError(t, vd.ValidateWith(patchUser{Email: "bad"}, vd.Partial("email"), vd.WithTags()))
+	assert.Equal(t, "Email", verr.Path.String())
+
+	verr = asValidationError(t, vd.ValidateWith(patchUser{Address: patchAddress{Zip: "1"}}, vd.Partial("address.zip"), vd.WithTags()))
+	assert.Equal(t, "Address.Zip", verr.Path.String())
+	assert.NoError(t, vd.ValidateWith(patchUser{Address: patchAddress{Zip: "12345"}}, vd.Partial("address.zip"), vd.WithTags()))
+	verr = asValidationError(t, vd.ValidateWith(patchUser{Address: patchAddress{Zip: "12345"}}, vd.Partial("address.zip", "Address"), vd.WithTags()))
+	assert.Equal(t, "Address.City", verr.Path.String())
+
+	tags := patchUser{Tags: map[string]qty{"a": 0, "b": 1}}
+	assert.NoError(t, vd.ValidateWith(tags, vd.Partial("tags.b"), vd.WithTags()))
+	verr = asValidationError(t, vd.ValidateWith(tags, vd.Partial("tags.a"), vd.WithConcurrency(2), vd.WithTags()))
+	assert.Equal(t, `Tags["a"]`, verr.Path.String())
+	errs := vd.Errors(vd.ValidateWith([]patchUser{tags, {Age: 1}}, vd.Partial("age", "tags"), vd.WithCollectAll(), vd.WithTags()))
+	assert.Len(t, errs, 3)
+
+	assert.Error(t, vd.ValidateWith(patchAddress{}, vd.WithTags()))
+	assert.NoError(t, vd.ValidateWith(patchAddress{Zip: "12345"}, vd.Partial("zip"), vd.WithTags()))
+	assert.NoError(t, vd.NewWalker(vd.Partial("zip"), vd.WithTags()).Validate(patchAddress{Zip: "12345"}))
+}
+
+type patchLimits struct {
+	MaxItems int `validate:"gte=1"`
+}
+
+func TestPartialMatchesKeysLikeJSON(t *testing.T) {
+	t.Parallel()
+
+	data := []byte(`{"maxitems": 0}`)
+	var limits patchLimits
+	assert.NoError(t, json.Unmarshal(data, &limits))
+	paths, err := vd.JSONPaths(data)
+	assert.NoError(t, err)
+	verr := asValidationError(t, vd.ValidateWith(limits, vd.Partial(paths...), vd.WithTags()))
+	assert.Equal(t, "MaxItems", verr.Path.String())
+
+	verr = asValidationError(t, vd.ValidateWith(patchUser{Age: 10}, vd.Partial("AGE"), vd.WithTags()))
+	assert.Equal(t, "Age", verr.Path.String())
+	assert.NoError(t, vd.ValidateWith(patchUser{Age: 10}, vd.Partial("ages"), vd.WithTags()))
+}
+
+func TestJSONPaths(t *testing.T) {
+	t.Parallel()
+
+	paths, err := vd.JSONPaths([]byte(`{"name": "", "address": {"zip": "1"}, "tags": {"a": 0}, "list": [1], "empty": {}, "null": null}`))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"address.zip", "empty", "list", "name", "null", "tags.a"}, paths)
+
+	paths, err = vd.JSONPaths([]byte(`{"address": {}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"address"}, paths)
+	verr := asValidationError(t, vd.ValidateWith(patchUser{}, vd.Partial(paths...), vd.WithTags()))
+	assert.Equal(t, "Address.City", verr.Path.String())
+
+	paths, err = vd.JSONPaths([]byte(`{"age": 10}`))
+	assert.NoError(t, err)
+	verr = asValidationError(t, vd.ValidateWith(patchUser{Age: 10}, vd.Partial(paths...), vd.WithTags()))
+	assert.Equal(t, "Age", verr.Path.String())
+
+	for _, data := range []string{`[]`, `null`, `{`, `1`} {
+		_, err := vd.JSONPaths([]byte(data))
+		assert.Error(t, err, data)
+	}
+}
@@ -0,0 +1,122 @@
+package validol
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+type PathKind int
+
+const (
+	PathField PathKind = iota
+	PathIndex
+	PathMapKey
+	PathMapValue
+)
+
+type PathElem struct {
+	Kind  PathKind
+	Name  string
+	Index int
+	Key   any
+	// Tag is the tag of the struct field, if known.
+	Tag reflect.StructTag
+	// Embedded is true for an embedded struct field.
+	Embedded bool
+}
+
+type Path []PathElem
+
+func (p Path) String() string {
+	var sb strings.Builder
+	for i, elem := range p {
+		switch elem.Kind {
+		case PathField:
+			if i > 0 {
+				sb.WriteByte('.')
+			}
+			sb.WriteString(elem.Name)
+		case PathIndex:
+			sb.WriteString(fmtIndex(elem.Index))
+		case PathMapKey:
+			sb.WriteString("[key:" + fmtKey(elem.Key) + "]")
+		case PathMapValue:
+			sb.WriteString("[" + fmtKey(elem.Key) + "]")
+		}
+	}
+	return sb.String()
+}
+
+// JSONPointer returns the RFC 6901 JSON pointer of the path.
+// Fields are named by their json tags, embedded fields without a json name are flattened.
+// A value under a field with the `json:"-"` tag is not serialized,
+// so it points to the nearest serializable ancestor.
+// A map key has the pointer of its value, JSON has no syntax to point to a member name.
+func (p Path) JSONPointer() string {
+	var sb strings.Builder
+	for _, elem := range p {
+		switch elem.Kind {
+		case PathField:
+			if elem.Tag.Get("json") == "-" {
+				return sb.String()
+			}
+			name := jsonName(reflect.StructField{Tag: elem.Tag})
+			if name == "" && elem.Embedded {
+				continue
+			}
+			if name == "" {
+				name = elem.Name
+			}
+			writeToken(&sb, name)
+		case PathIndex:
+			writeToken(&sb, strconv.Itoa(elem.Index))
+		case PathMapKey, PathMapValue:
+			writeToken(&sb, fmt.Sprint(elem.Key))
+		}
+	}
+	return sb.String()
+}
+
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+func writeToken(sb *strings.Builder, token string) {
+	sb.WriteByte('/')
+	_, _ = pointerEscaper.WriteString(sb, token)
+}
+
+func fieldElem(name string) PathElem {
+	return PathElem{Kind: PathField, Name: name}
+}
+
+func structFieldElem(sf reflect.StructField) PathElem {
+	return PathElem{Kind: PathField, Name: sf.Name, Tag: sf.Tag, Embedded: sf.Anonymous}
+}
+
+// fieldElemOf returns the path element of the field name of T,
+// with the tag of the field if T is a struct or a pointer to one.
+func fieldElemOf[T any](name string) PathElem {
+	typ := reflect.TypeFor[T]()
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if typ.Kind() == reflect.Struct {
+		if sf, ok := typ.FieldByName(name); ok {
+			return structFieldElem(sf)
+		}
+	}
+	return fieldElem(name)
+}
+
+func indexElem(i int) PathElem {
+	return PathElem{Kind: PathIndex, Index: i}
+}
+
+func mapKeyElem(key any) PathElem {
+	return PathElem{Kind: PathMapKey, Key: key}
+}
+
+func mapValueElem(key any) PathElem {
+	return PathElem{Kind: PathMapValue, Key: key}
+}
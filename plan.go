@@ -0,0 +1,200 @@
+package validol
+
+import (
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	validatableType    = reflect.TypeFor[Validatable]()
+	validatableCtxType = reflect.TypeFor[ValidatableCtx]()
+)
+
+// typePlan describes how Walk traverses values of a type.
+type typePlan struct {
+	// skip is true if values of the type can not hold anything to validate.
+	skip bool
+	// validatable is true if values of the type may implement
+	// Validatable or ValidatableCtx.
+	validatable bool
+	// refined is true for Refined, its values are checked with checkRefined.
+	refined bool
+	// fields are the struct fields to visit.
+	fields []fieldPlan
+	// tagErr is the error of the struct tags compilation.
+	tagErr error
+	// version is the version of the rule registry the plan was built with.
+	version uint64
+}
+
+type fieldPlan struct {
+	index    int
+	name     string
+	elem     PathElem
+	jsonName string
+	embedded bool
+	rules    []compiledField
+}
+
+type planner struct {
+	tagName string
+	skip    map[reflect.Type]bool
+	plans   sync.Map // map[reflect.Type]*typePlan
+}
+
+func newPlanner(cfg config) *planner {
+	p := &planner{tagName: cfg.tagName}
+	if len(cfg.skipTypes) > 0 {
+		p.skip = make(map[reflect.Type]bool, len(cfg.skipTypes))
+		for _, typ := range cfg.skipTypes {
+			p.skip[typ] = true
+		}
+	}
+	return p
+}
+
+// rulesVersion is incremented on every rule registration,
+// the plans built with a previous version might have failed on the new rule.
+var rulesVersion atomic.Uint64
+
+var planners sync.Map // map[string]*planner, by plannerKey
+
+// plannerFor returns a shared planner for the tag name and the types to skip.
+func plannerFor(cfg config) *planner {
+	key := plannerKey(cfg)
+	if p, ok := planners.Load(key); ok {
+		return p.(*planner) //nolint:forcetypeassert // the cache holds only *planner
+	}
+	p, _ := planners.LoadOrStore(key, newPlanner(cfg))
+	return p.(*planner) //nolint:forcetypeassert // the cache holds only *planner
+}
+
+// plannerKey is the tag name followed by the sorted addresses of the types to skip,
+// so the same set of types given in any order shares a planner.
+func plannerKey(cfg config) string {
+	if len(cfg.skipTypes) == 0 {
+		return cfg.tagName
+	}
+	ids := make([]uintptr, 0, len(cfg.skipTypes))
+	for _, typ := range cfg.skipTypes {
+		if typ != nil {
+			ids = append(ids, reflect.ValueOf(typ).Pointer())
+		}
+	}
+	slices.Sort(ids)
+	var b strings.Builder
+	b.WriteString(cfg.tagName)
+	for _, id := range slices.Compact(ids) {
+		b.WriteByte(0)
+		b.WriteString(strconv.FormatUint(uint64(id), 16))
+	}
+	return b.String()
+}
+
+func (p *planner) planOf(typ reflect.Type) *typePlan {
+	// the version is loaded before the plan is built, so a plan that raced
+	// with a registration is older than the registry and is rebuilt next time
+	v := rulesVersion.Load()
+	if cached, ok := p.plans.Load(typ); ok {
+		if plan := cached.(*typePlan); plan.version == v { //nolint:forcetypeassert // the cache holds only *typePlan
+			return plan
+		}
+	}
+	plan := p.newPlan(typ)
+	plan.version = v
+	p.plans.Store(typ, plan)
+	return plan
+}
+
+func (p *planner) newPlan(typ reflect.Type) *typePlan {
+	if p.skip[typ] {
+		return &typePlan{skip: true}
+	}
+	if isRefined(typ) {
+		return &typePlan{refined: true}
+	}
+	plan := &typePlan{
+		validatable: typ.Kind() == reflect.Interface || implementsValidatable(typ),
+	}
+	if typ.Kind() == reflect.Struct {
+		rules, err := compileStruct(typ, p.tagName)
+		if err != nil {
+			plan.tagErr = err
+			return plan
+		}
+		for i := range typ.NumField() {
+			sf := typ.Field(i)
+			if !sf.IsExported() {
+				continue
+			}
+			field := fieldPlan{index: i, name: sf.Name, elem: structFieldElem(sf), jsonName: jsonName(sf)}
+			field.embedded = sf.Anonymous && field.jsonName == ""
+			if rules != nil {
+				for _, c := range rules.fields[i] {
+					if len(c.rules) > 0 {
+						field.rules = append(field.rules, c)
+					}
+				}
+			}
+			if len(field.rules) == 0 && !p.mayContain(sf.Type, map[reflect.Type]bool{}) {
+				continue
+			}
+			plan.fields = append(plan.fields, field)
+		}
+	}
+	plan.skip = !p.mayContain(typ, map[reflect.Type]bool{})
+	return plan
+}
+
+// mayContain reports whether values of typ may hold something to validate:
+// a Validatable, a ValidatableCtx, a Refined or a struct with tag rules.
+// Types that are being visited are assumed to hold nothing,
+// the other fields of the recursive type decide.
+//
+//nolint:cyclop
+func (p *planner) mayContain(typ reflect.Type, visiting map[reflect.Type]bool) bool {
+	if visiting[typ] || p.skip[typ] {
+		return false
+	}
+	if plan, ok := p.plans.Load(typ); ok {
+		return !plan.(*typePlan).skip //nolint:forcetypeassert // the cache holds only *typePlan
+	}
+	visiting[typ] = true
+	defer delete(visiting, typ)
+
+	if typ.Kind() == reflect.Interface || implementsValidatable(typ) || isRefined(typ) {
+		return true
+	}
+	switch typ.Kind() {
+	case reflect.Pointer, reflect.Array, reflect.Slice:
+		return p.mayContain(typ.Elem(), visiting)
+	case reflect.Map:
+		return p.mayContain(typ.Key(), visiting) || p.mayContain(typ.Elem(), visiting)
+	case reflect.Struct:
+		for i := range typ.NumField() {
+			sf := typ.Field(i)
+			if !sf.IsExported() {
+				continue
+			}
+			if len(lookupTags(sf.Tag, p.tagName)) > 0 || p.mayContain(sf.Type, visiting) {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+func implementsValidatable(typ reflect.Type) bool {
+	return typ.Implements(validatableType) || typ.Implements(validatableCtxType)
+}
+
+// isRefined reports whether typ is a Refined, a pointer to one only has its methods.
+func isRefined(typ reflect.Type) bool {
+	return typ.Kind() == reflect.Struct && typ.Implements(refinedType)
+}
@@ -0,0 +1,119 @@
+// Package problem renders validol errors as RFC 9457 problem details.
+package problem
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+
+	vd "github.com/cospectrum/validol"
+)
+
+// ContentType is the media type of problem details.
+const ContentType = "application/problem+json"
+
+// Details is an RFC 9457 problem details object with the validation errors.
+type Details struct {
+	Type     string  `json:"type,omitempty"`
+	Title    string  `json:"title"`
+	Status   int     `json:"status,omitempty"`
+	Detail   string  `json:"detail,omitempty"`
+	Instance string  `json:"instance,omitempty"`
+	Errors   []Error `json:"errors"`
+}
+
+// Error is a single validation error.
+// The Detail of an invalid map key is prefixed with "key: ",
+// since a key and its value share the pointer.
+type Error struct {
+	// Pointer is the RFC 6901 JSON pointer to the invalid value, e.g. /users/3/email.
+	Pointer string         `json:"pointer"`
+	Code    string         `json:"code,omitempty"`
+	Detail  string         `json:"detail"`
+	Params  map[string]any `json:"params,omitempty"`
+}
+
+type Option func(*Details, *config)
+
+type config struct {
+	translator vd.Translator
+}
+
+func WithType(uri string) Option {
+	return func(d *Details, _ *config) {
+		d.Type = uri
+	}
+}
+
+func WithTitle(title string) Option {
+	return func(d *Details, _ *config) {
+		d.Title = title
+	}
+}
+
+func WithStatus(status int) Option {
+	return func(d *Details, _ *config) {
+		d.Status = status
+	}
+}
+
+func WithDetail(detail string) Option {
+	return func(d *Details, _ *config) {
+		d.Detail = detail
+	}
+}
+
+func WithInstance(uri string) Option {
+	return func(d *Details, _ *config) {
+		d.Instance = uri
+	}
+}
+
+// WithTranslator renders the details of the errors with t instead of validol.English.
+func WithTranslator(t vd.Translator) Option {
+	return func(_ *Details, c *config) {
+		c.translator = t
+	}
+}
+
+// New returns the problem details of err, one entry per validation error.
+// The default status is 422 Unprocessable Entity.
+func New(err error, opts ...Option) *Details {
+	d := &Details{
+		Title:  "Validation failed",
+		Status: http.StatusUnprocessableEntity,
+	}
+	cfg := config{translator: vd.English}
+	for _, opt := range opts {
+		opt(d, &cfg)
+	}
+	verrs := vd.Errors(err)
+	d.Errors = make([]Error, 0, len(verrs))
+	for _, verr := range verrs {
+		detail := cfg.translator.Translate(verr)
+		if n := len(verr.Path); n > 0 && verr.Path[n-1].Kind == vd.PathMapKey {
+			detail = "key: " + detail
+		}
+		d.Errors = append(d.Errors, Error{
+			Pointer: verr.Path.JSONPointer(),
+			Code:    verr.Code,
+			Detail:  detail,
+			Params:  verr.Params,
+		})
+	}
+	return d
+}
+
+// Write writes d as the response with the problem details content type.
+// Nothing is written if d can not be encoded, e.g. because of a param,
+// so the caller can still respond with an error.
+func (d *Details) Write(w http.ResponseWriter) error {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(d); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(d.Status)
+	_, err := buf.WriteTo(w)
+	return err
+}
@@ -0,0 +1,99 @@
+package problem_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	vd "github.com/cospectrum/validol"
+	"github.com/cospectrum/validol/problem"
+	"github.com/stretchr/testify/assert"
+)
+
+type address struct {
+	City string `json:"city" validate:"required"`
+}
+
+type user struct {
+	Name      string             `json:"name"  validate:"min_len=2"`
+	Age       int                `json:"age"   validate:"gte=18"`
+	Addresses []address          `json:"addresses"`
+	Tags      map[string]address `json:"tags"`
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	in := user{
+		Name:      "J",
+		Age:       18,
+		Addresses: []address{{City: "Berlin"}, {}},
+		Tags:      map[string]address{"home/1": {}},
+	}
+	details := problem.New(vd.WalkWith(in, vd.WithCollectAll(), vd.WithTags()))
+	assert.Equal(t, "Validation failed", details.Title)
+	assert.Equal(t, http.StatusUnprocessableEntity, details.Status)
+	assert.Equal(t, []problem.Error{
+		{Pointer: "/name", Code: "len_gte", Detail: "length must be at least 2", Params: map[string]any{"val": 2}},
+		{Pointer: "/addresses/1/city", Code: "required", Detail: "is required"},
+		{Pointer: "/tags/home~11/city", Code: "required", Detail: "is required"},
+	}, details.Errors)
+
+	details = problem.New(
+		errors.Join(vd.Gt(1)(0), errors.New("failing")),
+		problem.WithType("https://example.com/probs/validation"),
+		problem.WithTitle("Invalid request"),
+		problem.WithStatus(http.StatusBadRequest),
+		problem.WithDetail("2 errors"),
+		problem.WithInstance("/users/1"),
+		problem.WithTranslator(vd.Catalog{"gt": "doit être supérieur à {val}"}),
+	)
+	assert.Equal(t, &problem.Details{
+		Type:     "https://example.com/probs/validation",
+		Title:    "Invalid request",
+		Status:   http.StatusBadRequest,
+		Detail:   "2 errors",
+		Instance: "/users/1",
+		Errors: []problem.Error{
+			{Code: "gt", Detail: "doit être supérieur à 1", Params: map[string]any{"val": 1}},
+			{Detail: "failing"},
+		},
+	}, details)
+
+	assert.Empty(t, problem.New(nil).Errors)
+
+	keys := vd.Keys[map[string]int](vd.Len[string](vd.Gte(2)))
+	details = problem.New(keys(map[string]int{"a": 1}))
+	assert.Equal(t, []problem.Error{
+		{Pointer: "/a", Code: "len_gte", Detail: "key: length must be at least 2", Params: map[string]any{"val": 2}},
+	}, details.Errors)
+}
+
+func TestWrite(t *testing.T) {
+	t.Parallel()
+
+	rec := httptest.NewRecorder()
+	err := problem.New(vd.WalkWith(user{Name: "John", Age: 1}, vd.WithTags())).Write(rec)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Equal(t, problem.ContentType, rec.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{
+		"title": "Validation failed",
+		"status": 422,
+		"errors": [{"pointer": "/age", "code": "gte", "detail": "must be greater than or equal to 18", "params": {"val": 18}}]
+	}`, rec.Body.String())
+
+	var decoded problem.Details
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &decoded))
+	assert.Equal(t, "/age", decoded.Errors[0].Pointer)
+
+	rec = httptest.NewRecorder()
+	details := &problem.Details{Status: http.StatusUnprocessableEntity, Errors: []problem.Error{
+		{Detail: "unsupported", Params: map[string]any{"val": func() {}}},
+	}}
+	assert.Error(t, details.Write(rec))
+	assert.Empty(t, rec.Header().Get("Content-Type"))
+	assert.Empty(t, rec.Body.String())
+}
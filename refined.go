@@ -0,0 +1,135 @@
+package validol
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// Rule is a named validator of T, usually an empty struct:
+//
+//	type Positive struct{}
+//
+//	func (Positive) Validate(n int) error { return vd.Gt(0)(n) }
+type Rule[T any] interface {
+	Validate(t T) error
+}
+
+// Refined holds a value of T that passed the rule R,
+// e.g. Refined[int, Positive].
+// A Refined checked by R can only be obtained with NewRefined or by decoding,
+// Walk trusts such values and checks the others, e.g. the zero value, with R.
+type Refined[T any, R Rule[T]] struct {
+	value T
+	// ok is true if the value was checked by R
+	ok bool
+	// failed is the error of the last decode, see Validated
+	failed *decodeError
+}
+
+// NewRefined checks t with the rule R and wraps it, T is inferred:
+//
+//	n, err := vd.NewRefined[Positive](42)
+func NewRefined[R Rule[T], T any](t T) (Refined[T, R], error) {
+	var r R
+	if err := r.Validate(t); err != nil {
+		return Refined[T, R]{}, err
+	}
+	return Refined[T, R]{value: t, ok: true}, nil
+}
+
+func (r Refined[T, R]) Get() T {
+	return r.value
+}
+
+// checkRefined returns nil if the value was checked by R,
+// or the error of R for the value, e.g. the zero value of a missing JSON key.
+func (r Refined[T, R]) checkRefined() error {
+	if r.ok {
+		return nil
+	}
+	var rule R
+	return rule.Validate(r.value)
+}
+
+// refinedValue is implemented by Refined, Walk checks such values with checkRefined.
+type refinedValue interface {
+	checkRefined() error
+}
+
+var refinedType = reflect.TypeFor[refinedValue]()
+
+var (
+	_ json.Unmarshaler         = &Refined[int, Rule[int]]{}
+	_ json.Marshaler           = Refined[int, Rule[int]]{}
+	_ encoding.TextUnmarshaler = &Refined[int, Rule[int]]{}
+	_ encoding.TextMarshaler   = Refined[int, Rule[int]]{}
+	_ sql.Scanner              = &Refined[int, Rule[int]]{}
+	_ driver.Valuer            = Refined[int, Rule[int]]{}
+)
+
+func (r *Refined[T, R]) UnmarshalJSON(data []byte) error {
+	var t T
+	if err := json.Unmarshal(data, &t); err != nil {
+		return r.fail(located(&t, err))
+	}
+	return r.set(t)
+}
+
+func (r Refined[T, R]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.value)
+}
+
+// UnmarshalText decodes T like Validated.UnmarshalText and checks it with R.
+func (r *Refined[T, R]) UnmarshalText(text []byte) error {
+	var t T
+	if err := unmarshalText(&t, text); err != nil {
+		return r.fail(located(&t, err))
+	}
+	return r.set(t)
+}
+
+func (r Refined[T, R]) MarshalText() ([]byte, error) {
+	return marshalText(r.value)
+}
+
+// Scan converts src to T like sql.Null[T] and checks it with R,
+// NULL is an error, scan into *Refined for a nullable column.
+func (r *Refined[T, R]) Scan(src any) error {
+	var n sql.Null[T]
+	if err := n.Scan(src); err != nil {
+		return fmt.Errorf("validol: %w", err)
+	}
+	if !n.Valid {
+		return fmt.Errorf("validol: can not scan NULL into %T", r)
+	}
+	return r.set(n.V)
+}
+
+// Value converts the value with driver.DefaultParameterConverter.
+func (r Refined[T, R]) Value() (driver.Value, error) {
+	return driver.DefaultParameterConverter.ConvertValue(r.value)
+}
+
+func (r *Refined[T, R]) set(t T) error {
+	var rule R
+	if err := rule.Validate(t); err != nil {
+		return r.fail(err)
+	}
+	r.value = t
+	r.ok = true
+	r.failed = nil
+	return nil
+}
+
+func (r *Refined[T, R]) fail(err error) error {
+	r.failed = &decodeError{err: err}
+	return r.failed
+}
+
+func (r Refined[T, R]) decodeFailure() *decodeError {
+	return r.failed
+}
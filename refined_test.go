@@ -0,0 +1,164 @@
+package validol_test
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+	"time"
+
+	vd "github.com/cospectrum/validol"
+	"github.com/stretchr/testify/assert"
+)
+
+type positive struct{}
+
+func (positive) Validate(n int) error {
+	return vd.Gt(0)(n)
+}
+
+type nonBlank struct{}
+
+func (nonBlank) Validate(s string) error {
+	return vd.Required[string](s)
+}
+
+type (
+	positiveInt = vd.Refined[int, positive]
+	name        = vd.Refined[string, nonBlank]
+)
+
+type refinedLine struct {
+	Name name        `json:"name"`
+	Qty  positiveInt `json:"qty"`
+}
+
+type refinedOrder struct {
+	Lines []vd.Validated[refinedLine] `json:"lines"`
+	Total positiveInt                 `json:"total"`
+}
+
+func TestNewRefined(t *testing.T) {
+	t.Parallel()
+
+	n, err := vd.NewRefined[positive](42)
+	assert.NoError(t, err)
+	assert.Equal(t, 42, n.Get())
+
+	_, err = vd.NewRefined[positive](0)
+	assert.Equal(t, "Gt", asValidationError(t, err).Rule)
+}
+
+func TestRefinedJSON(t *testing.T) {
+	t.Parallel()
+
+	var line refinedLine
+	assert.NoError(t, json.Unmarshal([]byte(`{"name": "a", "qty": 2}`), &line))
+	assert.Equal(t, "a", line.Name.Get())
+	assert.Equal(t, 2, line.Qty.Get())
+	data, err := json.Marshal(line)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"name": "a", "qty": 2}`, string(data))
+
+	err = json.Unmarshal([]byte(`{"name": "a", "qty": -1}`), &line)
+	assert.Equal(t, "Gt", asValidationError(t, err).Rule)
+	assert.Equal(t, 2, line.Qty.Get())
+	assert.Error(t, json.Unmarshal([]byte(`{"qty": "1"}`), &line))
+
+	var order vd.Validated[refinedOrder]
+	err = json.Unmarshal([]byte(`{"lines": [{"name": "a", "qty": 1}, {"name": "", "qty": 1}], "total": 2}`), &order)
+	verr := asValidationError(t, err)
+	assert.Equal(t, "/lines/1/name", verr.Path.JSONPointer())
+	assert.Equal(t, "Required", verr.Rule)
+	err = json.Unmarshal([]byte(`{"total": 0}`), &order)
+	assert.Equal(t, "/total", asValidationError(t, err).Path.JSONPointer())
+}
+
+func TestRefinedText(t *testing.T) {
+	t.Parallel()
+
+	var n positiveInt
+	assert.NoError(t, n.UnmarshalText([]byte("7")))
+	assert.Equal(t, 7, n.Get())
+	assert.Error(t, n.UnmarshalText([]byte("0")))
+	assert.Error(t, n.UnmarshalText([]byte("x")))
+	text, err := n.MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "7", string(text))
+
+	var byName map[name]int
+	assert.NoError(t, json.Unmarshal([]byte(`{"a": 1}`), &byName))
+	assert.Error(t, json.Unmarshal([]byte(`{"": 1}`), &byName))
+}
+
+func TestRefinedSQL(t *testing.T) {
+	t.Parallel()
+
+	var n positiveInt
+	assert.NoError(t, n.Scan(int64(3)))
+	assert.Equal(t, 3, n.Get())
+	assert.NoError(t, n.Scan([]byte("4")))
+	assert.Equal(t, 4, n.Get())
+	assert.Equal(t, "Gt", asValidationError(t, n.Scan(int64(0))).Rule)
+	assert.Error(t, n.Scan("x"))
+	assert.Error(t, n.Scan(nil))
+	assert.Equal(t, 4, n.Get())
+
+	val, err := n.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, driver.Value(int64(4)), val)
+
+	ts, err := vd.NewRefined[notZeroTime](time.Unix(1, 0).UTC())
+	assert.NoError(t, err)
+	val, err = ts.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, driver.Value(time.Unix(1, 0).UTC()), val)
+}
+
+type notZeroTime struct{}
+
+func (notZeroTime) Validate(t time.Time) error {
+	return vd.Not(vd.Empty[time.Time])(t)
+}
+
+// anyItem accepts every item, even an invalid one.
+type anyItem struct{}
+
+func (anyItem) Validate(item) error {
+	return nil
+}
+
+func TestWalkTrustsConstructedRefined(t *testing.T) {
+	t.Parallel()
+
+	invalid, err := vd.NewRefined[anyItem](item{Qty: 0})
+	assert.NoError(t, err)
+	assert.Error(t, vd.Walk(invalid.Get()))
+	assert.NoError(t, vd.Walk(struct {
+		R   vd.Refined[item, anyItem]
+		P   *vd.Refined[item, anyItem]
+		S   []vd.Refined[item, anyItem]
+		Any any
+	}{R: invalid, P: &invalid, S: []vd.Refined[item, anyItem]{invalid}, Any: invalid}))
+}
+
+func TestWalkChecksZeroRefined(t *testing.T) {
+	t.Parallel()
+
+	verr := asValidationError(t, vd.Validate(struct{ Age positiveInt }{}))
+	assert.Equal(t, "Age", verr.Path.String())
+	assert.Equal(t, "Gt", verr.Rule)
+	verr = asValidationError(t, vd.Walk(struct{ Ages []positiveInt }{Ages: make([]positiveInt, 2)}))
+	assert.Equal(t, "Ages[0]", verr.Path.String())
+	assert.NoError(t, vd.Walk(struct{ Age *positiveInt }{}))
+
+	var zero vd.Refined[item, anyItem]
+	assert.NoError(t, vd.Walk(struct{ R vd.Refined[item, anyItem] }{R: zero}))
+
+	var line vd.Validated[refinedLine]
+	err := json.Unmarshal([]byte(`{"name": "a"}`), &line)
+	assert.Equal(t, "/qty", asValidationError(t, err).Path.JSONPointer())
+
+	n, err := vd.NewRefined[positive](1)
+	assert.NoError(t, err)
+	assert.NoError(t, vd.Validate(struct{ Age positiveInt }{Age: n}))
+}
@@ -0,0 +1,86 @@
+package validol
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sync"
+)
+
+type RuleFactory func(typ reflect.Type, params []string) (Validator[any], error)
+
+var ruleNameRegex = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]*$`)
+
+type ruleRegistry struct {
+	mu    sync.RWMutex
+	rules map[string]ruleFactory
+}
+
+var registry = &ruleRegistry{rules: builtinRules}
+
+func (r *ruleRegistry) lookup(name string) (ruleFactory, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	factory, ok := r.rules[name]
+	return factory, ok
+}
+
+func (r *ruleRegistry) register(name string, factory ruleFactory) {
+	if !ruleNameRegex.MatchString(name) || name == omitEmpty {
+		panic(fmt.Sprintf("validol: invalid rule name %q", name))
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.rules[name]; ok {
+		panic(fmt.Sprintf("validol: rule %q is already registered", name))
+	}
+	r.rules[name] = factory
+	rulesVersion.Add(1)
+}
+
+func RegisterRule(name string, factory RuleFactory) {
+	if factory == nil {
+		panic(fmt.Sprintf("validol: nil factory for rule %q", name))
+	}
+	registry.register(name, valueRule(func(typ reflect.Type, params []string) (Validator[reflect.Value], error) {
+		fn, err := factory(typ, params)
+		if err != nil {
+			return nil, err
+		}
+		return func(v reflect.Value) error {
+			return fn(v.Interface())
+		}, nil
+	}))
+}
+
+func RegisterValidator[T any](name string, fn Validator[T]) {
+	target := reflect.TypeFor[T]()
+	RegisterRule(name, func(typ reflect.Type, params []string) (Validator[any], error) {
+		if len(params) != 0 {
+			return nil, errUnexpectedParams
+		}
+		if !applicable(typ, target) {
+			return nil, notApplicable(typ)
+		}
+		return func(v any) error {
+			if t, ok := v.(T); ok {
+				return fn(t)
+			}
+			if v == nil {
+				var zero T
+				return fn(zero)
+			}
+			return fn(reflect.ValueOf(v).Convert(target).Interface().(T)) //nolint:forcetypeassert // converted above
+		}, nil
+	})
+}
+
+// applicable reports whether the values of typ can be passed to a validator of target,
+// as is or converted between types of the same kind, e.g. a named string to string.
+// Other conversions, e.g. int to string, change the value and are not allowed.
+func applicable(typ, target reflect.Type) bool {
+	if typ.AssignableTo(target) {
+		return true
+	}
+	return typ.Kind() == target.Kind() && target.Kind() != reflect.Interface && typ.ConvertibleTo(target)
+}
@@ -0,0 +1,159 @@
+package validol_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	vd "github.com/cospectrum/validol"
+	"github.com/stretchr/testify/assert"
+)
+
+type tenantID string
+
+func init() {
+	vd.RegisterValidator("starts_with_a", vd.StartsWith("A"))
+	vd.RegisterValidator("positive_int", vd.Gt(0))
+	vd.RegisterValidator("stringer", func(s fmt.Stringer) error { return vd.Ne("")(s.String()) })
+	vd.RegisterValidator("tenant_id", vd.StartsWith("tenant-"))
+	vd.RegisterRule("divisible_by", func(typ reflect.Type, params []string) (vd.Validator[any], error) {
+		if typ.Kind() != reflect.Int || len(params) != 1 {
+			return nil, errors.New("divisible_by expects an int and a single parameter")
+		}
+		n, err := strconv.Atoi(params[0])
+		if err != nil {
+			return nil, err
+		}
+		return func(v any) error {
+			return vd.Eq(0)(v.(int) % n)
+		}, nil
+	})
+}
+
+type registered struct {
+	Tenant  tenantID `validate:"tenant_id"`
+	Tenants []string
+	Batch   *int `validate:"omitempty,divisible_by=10"`
+}
+
+func TestRegisterRule(t *testing.T) {
+	t.Parallel()
+
+	batch := 20
+	assert.NoError(t, vd.WalkWith(registered{Tenant: "tenant-1", Batch: &batch}, vd.WithTags()))
+	assert.NoError(t, vd.WalkWith(registered{Tenant: "tenant-1"}, vd.WithTags()))
+
+	verr := asValidationError(t, vd.WalkWith(registered{Tenant: "1"}, vd.WithTags()))
+	assert.Equal(t, "Tenant", verr.Path.String())
+	assert.Equal(t, "StartsWith", verr.Rule)
+
+	batch = 15
+	verr = asValidationError(t, vd.WalkWith(registered{Tenant: "tenant-1", Batch: &batch}, vd.WithTags()))
+	assert.Equal(t, "Batch", verr.Path.String())
+	assert.Equal(t, "Eq", verr.Rule)
+
+	var tagErr *vd.TagError
+	err := vd.WalkWith(struct {
+		Batch string `validate:"divisible_by=10"`
+	}{}, vd.WithTags())
+	assert.True(t, errors.As(err, &tagErr))
+
+	err = vd.WalkWith(struct {
+		Tenant int `validate:"tenant_id=1"`
+	}{}, vd.WithTags())
+	assert.True(t, errors.As(err, &tagErr))
+
+	one := qty(1)
+	// 65 would be converted to "A"
+	err = vd.WalkWith(struct {
+		Code int `validate:"starts_with_a"`
+	}{Code: 65}, vd.WithTags())
+	assert.True(t, errors.As(err, &tagErr))
+	err = vd.WalkWith(struct {
+		Ratio float64 `validate:"positive_int"`
+	}{Ratio: 0.5}, vd.WithTags())
+	assert.True(t, errors.As(err, &tagErr))
+	assert.NoError(t, vd.WalkWith(struct {
+		Code tenantID     `validate:"starts_with_a"`
+		N    *qty         `validate:"positive_int"`
+		Any  fmt.Stringer `validate:"omitempty,stringer"`
+	}{Code: "A1", N: &one}, vd.WithTags()))
+}
+
+func TestRegisterRulePanics(t *testing.T) {
+	t.Parallel()
+
+	noop := func(reflect.Type, []string) (vd.Validator[any], error) { return nil, nil }
+	assert.Panics(t, func() { vd.RegisterRule("gt", noop) })
+	assert.Panics(t, func() { vd.RegisterRule("tenant_id", noop) })
+	assert.Panics(t, func() { vd.RegisterRule("omitempty", noop) })
+	assert.Panics(t, func() { vd.RegisterRule("a=b", noop) })
+	assert.Panics(t, func() { vd.RegisterRule("", noop) })
+	assert.Panics(t, func() { vd.RegisterRule("new_rule", nil) })
+}
+
+var lateRules atomic.Int64
+
+// lateRule returns a new rule name, so the test registers it on every run,
+// and a constructor of a struct with the field tagged with it.
+func lateRule() (string, func(string) any) {
+	name := fmt.Sprintf("late_rule_%d", lateRules.Add(1))
+	typ := reflect.StructOf([]reflect.StructField{{
+		Name: "Field",
+		Type: reflect.TypeFor[string](),
+		Tag:  reflect.StructTag(`validate:"` + name + `"`),
+	}})
+	return name, func(field string) any {
+		val := reflect.New(typ).Elem()
+		val.Field(0).SetString(field)
+		return val.Interface()
+	}
+}
+
+func TestRegisterRuleAfterFirstUse(t *testing.T) {
+	t.Parallel()
+
+	name, newValue := lateRule()
+	var tagErr *vd.TagError
+	assert.True(t, errors.As(vd.WalkWith(newValue(""), vd.WithTags()), &tagErr))
+
+	vd.RegisterValidator(name, vd.Required[string])
+	assert.NoError(t, vd.WalkWith(newValue("x"), vd.WithTags()))
+	assert.Error(t, vd.WalkWith(newValue(""), vd.WithTags()))
+}
+
+type racingRule struct {
+	Field string `validate:"racing_rule"`
+}
+
+var registerRacingRule sync.Once
+
+func TestRegisterRuleConcurrently(t *testing.T) {
+	t.Parallel()
+
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+					_ = vd.WalkWith(racingRule{Field: "x"}, vd.WithTags())
+				}
+			}
+		}()
+	}
+	registerRacingRule.Do(func() { vd.RegisterValidator("racing_rule", vd.Required[string]) })
+	close(stop)
+	wg.Wait()
+	assert.NoError(t, vd.WalkWith(racingRule{Field: "x"}, vd.WithTags()))
+	assert.Error(t, vd.WalkWith(racingRule{}, vd.WithTags()))
+}
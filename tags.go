@@ -0,0 +1,400 @@
+package validol
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultTagName = "validate"
+	omitEmpty      = "omitempty"
+)
+
+type TagError struct {
+	Type  reflect.Type
+	Field string
+	Tag   string
+	Err   error
+}
+
+var _ error = &TagError{}
+
+func (e *TagError) Error() string {
+	return fmt.Sprintf("validol: invalid tag %q on %s.%s: %v", e.Tag, e.Type, e.Field, e.Err)
+}
+
+func (e *TagError) Unwrap() error {
+	return e.Err
+}
+
+// fieldRule validates a struct field, parent is the struct that holds it.
+type fieldRule func(parent, field reflect.Value) error
+
+type ruleFactory func(parent reflect.Type, field reflect.StructField, params []string) (fieldRule, error)
+
+var builtinRules = map[string]ruleFactory{
+	"required": anyRule(Required[reflect.Value]),
+	"empty":    anyRule(Empty[reflect.Value]),
+	"nil":      anyRule(Nil[reflect.Value]),
+	"not_nil":  anyRule(NotNil[reflect.Value]),
+
+	"eq":     comparableRule(Eq[any]),
+	"ne":     comparableRule(Ne[any]),
+	"one_of": oneOfRule,
+
+	"gt":  orderedRule(Gt[int64], Gt[uint64], Gt[float64], Gt[string]),
+	"gte": orderedRule(Gte[int64], Gte[uint64], Gte[float64], Gte[string]),
+	"lt":  orderedRule(Lt[int64], Lt[uint64], Lt[float64], Lt[string]),
+	"lte": orderedRule(Lte[int64], Lte[uint64], Lte[float64], Lte[string]),
+
+	"len":     lenRule(Eq[int]),
+	"min_len": lenRule(Gte[int]),
+	"max_len": lenRule(Lte[int]),
+
+	"starts_with": stringRule(StartsWith),
+	"ends_with":   stringRule(EndsWith),
+	"contains":    stringRule(Contains),
+	"email":       stringRule(func(string) Validator[string] { return Email }),
+	"uuid4":       stringRule(func(string) Validator[string] { return UUID4 }),
+
+	"eq_field":      crossFieldRule("EqField", false, func(c int) bool { return c == 0 }),
+	"ne_field":      crossFieldRule("NeField", false, func(c int) bool { return c != 0 }),
+	"gt_field":      crossFieldRule("GtField", true, func(c int) bool { return c > 0 }),
+	"gte_field":     crossFieldRule("GteField", true, func(c int) bool { return c >= 0 }),
+	"lt_field":      crossFieldRule("LtField", true, func(c int) bool { return c < 0 }),
+	"lte_field":     crossFieldRule("LteField", true, func(c int) bool { return c <= 0 }),
+	"required_if":   requiredIfRule,
+	"required_with": requiredWithRule,
+}
+
+type structRules struct {
+	fields [][]compiledField
+}
+
+// compiledField holds the rules of one tag,
+// group is empty for the rules that always run.
+type compiledField struct {
+	group     string
+	omitEmpty bool
+	rules     []fieldRule
+}
+
+func (c compiledField) validate(parent, field reflect.Value) error {
+	if c.omitEmpty && isEmpty(field) {
+		return nil
+	}
+	for _, rule := range c.rules {
+		if err := rule(parent, field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func compileStruct(typ reflect.Type, tagName string) (*structRules, error) {
+	out := &structRules{fields: make([][]compiledField, typ.NumField())}
+	found := false
+	for i := range typ.NumField() {
+		sf := typ.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		for _, tag := range lookupTags(sf.Tag, tagName) {
+			field, err := compileTag(typ, sf, tag.value)
+			if err != nil {
+				return nil, &TagError{Type: typ, Field: sf.Name, Tag: tag.value, Err: err}
+			}
+			field.group = tag.group
+			out.fields[i] = append(out.fields[i], field)
+			found = true
+		}
+	}
+	if !found {
+		return nil, nil //nolint:nilnil // no tagged fields
+	}
+	return out, nil
+}
+
+type groupTag struct {
+	group string
+	value string
+}
+
+// lookupTags returns the values of the tagName key and of the
+// tagName.<group> keys in the order of the struct tag.
+// The group of the tagName key is empty.
+func lookupTags(tag reflect.StructTag, tagName string) []groupTag {
+	if tagName == "" {
+		// the tag rules are disabled
+		return nil
+	}
+	var out []groupTag
+	for tag != "" {
+		// the loop follows reflect.StructTag.Lookup
+		i := 0
+		for i < len(tag) && tag[i] == ' ' {
+			i++
+		}
+		tag = tag[i:]
+		if tag == "" {
+			break
+		}
+		i = 0
+		for i < len(tag) && tag[i] > ' ' && tag[i] != ':' && tag[i] != '"' && tag[i] != 0x7f {
+			i++
+		}
+		if i == 0 || i+1 >= len(tag) || tag[i] != ':' || tag[i+1] != '"' {
+			break
+		}
+		name := string(tag[:i])
+		tag = tag[i+1:]
+
+		i = 1
+		for i < len(tag) && tag[i] != '"' {
+			if tag[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(tag) {
+			break
+		}
+		quoted := string(tag[:i+1])
+		tag = tag[i+1:]
+
+		group, ok := strings.CutPrefix(name, tagName)
+		if !ok || (group != "" && (group[0] != '.' || len(group) == 1)) {
+			continue
+		}
+		value, err := strconv.Unquote(quoted)
+		if err != nil {
+			break
+		}
+		out = append(out, groupTag{group: strings.TrimPrefix(group, "."), value: value})
+	}
+	return out
+}
+
+func compileTag(parent reflect.Type, sf reflect.StructField, tag string) (compiledField, error) {
+	var out compiledField
+	for _, part := range strings.Split(tag, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, rawParams, _ := strings.Cut(part, "=")
+		if name == omitEmpty {
+			out.omitEmpty = true
+			continue
+		}
+		factory, ok := registry.lookup(name)
+		if !ok {
+			return out, fmt.Errorf("unknown rule %q", name)
+		}
+		rule, err := factory(parent, sf, strings.Fields(rawParams))
+		if err != nil {
+			return out, fmt.Errorf("rule %q: %w", name, err)
+		}
+		out.rules = append(out.rules, rule)
+	}
+	return out, nil
+}
+
+func anyRule(fn Validator[reflect.Value]) ruleFactory {
+	return func(_ reflect.Type, _ reflect.StructField, params []string) (fieldRule, error) {
+		if len(params) != 0 {
+			return nil, errUnexpectedParams
+		}
+		return func(_, field reflect.Value) error {
+			return fn(field)
+		}, nil
+	}
+}
+
+// valueRule builds a rule for the dereferenced field value,
+// nil pointers are skipped.
+func valueRule(build func(typ reflect.Type, params []string) (Validator[reflect.Value], error)) ruleFactory {
+	return func(_ reflect.Type, sf reflect.StructField, params []string) (fieldRule, error) {
+		typ := sf.Type
+		for typ.Kind() == reflect.Pointer {
+			typ = typ.Elem()
+		}
+		fn, err := build(typ, params)
+		if err != nil {
+			return nil, err
+		}
+		return func(_, field reflect.Value) error {
+			for field.Kind() == reflect.Pointer {
+				if field.IsNil() {
+					return nil
+				}
+				field = field.Elem()
+			}
+			return fn(field)
+		}, nil
+	}
+}
+
+func comparableRule(build func(any) Validator[any]) ruleFactory {
+	return valueRule(func(typ reflect.Type, params []string) (Validator[reflect.Value], error) {
+		param, err := singleParam(params)
+		if err != nil {
+			return nil, err
+		}
+		val, err := parseParam(typ, param)
+		if err != nil {
+			return nil, err
+		}
+		fn := build(val)
+		return func(v reflect.Value) error {
+			return fn(v.Interface())
+		}, nil
+	})
+}
+
+var oneOfRule = valueRule(func(typ reflect.Type, params []string) (Validator[reflect.Value], error) {
+	if len(params) == 0 {
+		return nil, errMissingParams
+	}
+	vals := make([]any, 0, len(params))
+	for _, param := range params {
+		val, err := parseParam(typ, param)
+		if err != nil {
+			return nil, err
+		}
+		vals = append(vals, val)
+	}
+	fn := OneOf(vals...)
+	return func(v reflect.Value) error {
+		return fn(v.Interface())
+	}, nil
+})
+
+//nolint:cyclop
+func orderedRule(
+	ints func(int64) Validator[int64],
+	uints func(uint64) Validator[uint64],
+	floats func(float64) Validator[float64],
+	strs func(string) Validator[string],
+) ruleFactory {
+	return valueRule(func(typ reflect.Type, params []string) (Validator[reflect.Value], error) {
+		param, err := singleParam(params)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case isIntKind(typ.Kind()):
+			p, err := strconv.ParseInt(param, 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			fn := ints(p)
+			return func(v reflect.Value) error { return fn(v.Int()) }, nil
+		case isUintKind(typ.Kind()):
+			p, err := strconv.ParseUint(param, 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			fn := uints(p)
+			return func(v reflect.Value) error { return fn(v.Uint()) }, nil
+		case isFloatKind(typ.Kind()):
+			p, err := strconv.ParseFloat(param, 64)
+			if err != nil {
+				return nil, err
+			}
+			fn := floats(p)
+			return func(v reflect.Value) error { return fn(v.Float()) }, nil
+		case typ.Kind() == reflect.String:
+			fn := strs(param)
+			return func(v reflect.Value) error { return fn(v.String()) }, nil
+		default:
+			return nil, notApplicable(typ)
+		}
+	})
+}
+
+func lenRule(build func(int) Validator[int]) ruleFactory {
+	return valueRule(func(typ reflect.Type, params []string) (Validator[reflect.Value], error) {
+		switch typ.Kind() {
+		case reflect.String, reflect.Slice, reflect.Array, reflect.Map, reflect.Chan:
+		default:
+			return nil, notApplicable(typ)
+		}
+		param, err := singleParam(params)
+		if err != nil {
+			return nil, err
+		}
+		n, err := strconv.Atoi(param)
+		if err != nil {
+			return nil, err
+		}
+		fn := Len[any](build(n))
+		return func(v reflect.Value) error { return fn(v.Interface()) }, nil
+	})
+}
+
+func stringRule(build func(string) Validator[string]) ruleFactory {
+	return valueRule(func(typ reflect.Type, params []string) (Validator[reflect.Value], error) {
+		if typ.Kind() != reflect.String {
+			return nil, notApplicable(typ)
+		}
+		param := strings.Join(params, " ")
+		fn := build(param)
+		return func(v reflect.Value) error { return fn(v.String()) }, nil
+	})
+}
+
+var (
+	errUnexpectedParams = errors.New("unexpected parameters")
+	errMissingParams    = errors.New("missing parameters")
+)
+
+func notApplicable(typ reflect.Type) error {
+	return fmt.Errorf("not applicable to %s", typ)
+}
+
+func singleParam(params []string) (string, error) {
+	if len(params) != 1 {
+		return "", fmt.Errorf("expected 1 parameter, got %d", len(params))
+	}
+	return params[0], nil
+}
+
+// parseParam converts a tag parameter to a value of type typ.
+func parseParam(typ reflect.Type, param string) (any, error) {
+	var val reflect.Value
+	switch kind := typ.Kind(); {
+	case kind == reflect.String:
+		val = reflect.ValueOf(param)
+	case kind == reflect.Bool:
+		b, err := strconv.ParseBool(param)
+		if err != nil {
+			return nil, err
+		}
+		val = reflect.ValueOf(b)
+	case isIntKind(kind):
+		n, err := strconv.ParseInt(param, 10, typ.Bits())
+		if err != nil {
+			return nil, err
+		}
+		val = reflect.ValueOf(n)
+	case isUintKind(kind):
+		n, err := strconv.ParseUint(param, 10, typ.Bits())
+		if err != nil {
+			return nil, err
+		}
+		val = reflect.ValueOf(n)
+	case isFloatKind(kind):
+		f, err := strconv.ParseFloat(param, typ.Bits())
+		if err != nil {
+			return nil, err
+		}
+		val = reflect.ValueOf(f)
+	default:
+		return nil, notApplicable(typ)
+	}
+	return val.Convert(typ).Interface(), nil
+}
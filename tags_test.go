@@ -0,0 +1,128 @@
+package validol_test
+
+import (
+	"errors"
+	"testing"
+
+	vd "github.com/cospectrum/validol"
+	"github.com/stretchr/testify/assert"
+)
+
+type tagged struct {
+	Name    string   `validate:"required,min_len=2,max_len=10"`
+	Age     int      `validate:"gte=18,lt=150"`
+	Score   *float64 `validate:"gt=0.5"`
+	Email   string   `validate:"omitempty,email"`
+	ID      string   `validate:"uuid4"`
+	Role    Sex      `validate:"one_of=male female"`
+	Tags    []string `validate:"len=2"`
+	Website string   `validate:"starts_with=https://,contains=.,ends_with=.com"`
+	Count   uint8    `validate:"ne=0"`
+	private int      `validate:"unknown"`
+	Nested  *tagged
+}
+
+func validTagged() tagged {
+	score := 1.0
+	return tagged{
+		Name:    "John",
+		Age:     30,
+		Score:   &score,
+		ID:      "57b73598-8764-4ad0-a76a-679bb6640eb1",
+		Role:    "male",
+		Tags:    []string{"a", "b"},
+		Website: "https://example.com",
+		Count:   1,
+	}
+}
+
+func TestTags(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, vd.WalkWith(validTagged(), vd.WithTags()))
+	assert.NoError(t, vd.ValidateWith([]tagged{validTagged()}, vd.WithTags()))
+
+	invalid := []struct {
+		mutate func(*tagged)
+		path   string
+		rule   string
+	}{
+		{func(v *tagged) { v.Name = "" }, "Name", "Required"},
+		{func(v *tagged) { v.Name = "J" }, "Name", "Gte"},
+		{func(v *tagged) { v.Name = "John Johnson Jr" }, "Name", "Lte"},
+		{func(v *tagged) { v.Age = 17 }, "Age", "Gte"},
+		{func(v *tagged) { v.Age = 150 }, "Age", "Lt"},
+		{func(v *tagged) { s := 0.5; v.Score = &s }, "Score", "Gt"},
+		{func(v *tagged) { v.Email = "invalid" }, "Email", "Email"},
+		{func(v *tagged) { v.ID = "" }, "ID", "UUID4"},
+		{func(v *tagged) { v.Role = "other" }, "Role", "OneOf"},
+		{func(v *tagged) { v.Tags = nil }, "Tags", "Eq"},
+		{func(v *tagged) { v.Website = "http://example.com" }, "Website", "StartsWith"},
+		{func(v *tagged) { v.Website = "https://example" }, "Website", "Contains"},
+		{func(v *tagged) { v.Website = "https://example.org" }, "Website", "EndsWith"},
+		{func(v *tagged) { v.Count = 0 }, "Count", "Ne"},
+		{func(v *tagged) { nested := validTagged(); nested.Age = 0; v.Nested = &nested }, "Nested.Age", "Gte"},
+	}
+	for _, tc := range invalid {
+		v := validTagged()
+		tc.mutate(&v)
+		verr := asValidationError(t, vd.WalkWith(v, vd.WithTags()))
+		assert.Equal(t, tc.path, verr.Path.String())
+		assert.Equal(t, tc.rule, verr.Rule)
+	}
+
+	v := validTagged()
+	v.Score = nil
+	assert.NoError(t, vd.WalkWith(v, vd.WithTags()))
+
+	v = tagged{}
+	errs := vd.Errors(vd.WalkWith(v, vd.WithCollectAll(), vd.WithTags()))
+	assert.Len(t, errs, 7)
+}
+
+type taggedEmail struct {
+	Email Email `validate:"required"`
+}
+
+func TestTagsWithValidatable(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, vd.WalkWith(taggedEmail{Email: "first_user@mail.com"}, vd.WithTags()))
+
+	verr := asValidationError(t, vd.WalkWith(taggedEmail{}, vd.WithTags()))
+	assert.Equal(t, "Required", verr.Rule)
+	assert.Equal(t, Email(""), verr.Value)
+
+	verr = asValidationError(t, vd.WalkWith(taggedEmail{Email: "abcdef"}, vd.WithTags()))
+	assert.Equal(t, "Email", verr.Rule)
+}
+
+type unknownRule struct {
+	Field int `validate:"gt=0,unknown"`
+}
+
+type invalidParam struct {
+	Field int `validate:"gt=abc"`
+}
+
+type notApplicable struct {
+	Field int `validate:"email"`
+}
+
+func TestTagErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, in := range []any{unknownRule{}, invalidParam{}, notApplicable{}} {
+		err := vd.WalkWith(in, vd.WithTags())
+		var tagErr *vd.TagError
+		if assert.True(t, errors.As(err, &tagErr)) {
+			assert.Equal(t, "Field", tagErr.Field)
+		}
+	}
+	err := vd.WalkWith(unknownRule{}, vd.WithTags())
+	assert.Equal(t, `validol: invalid tag "gt=0,unknown" on validol_test.unknownRule.Field: unknown rule "unknown"`, err.Error())
+
+	err = vd.WalkWith([]unknownRule{{}}, vd.WithCollectAll(), vd.WithTags())
+	var tagErr *vd.TagError
+	assert.True(t, errors.As(err, &tagErr))
+}
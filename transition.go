@@ -0,0 +1,198 @@
+package validol
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+type TransitionValidator[Of any] func(old, next Of) error
+
+type TransitionValidatable[T any] interface {
+	ValidateTransition(old T) error
+}
+
+func ValidateTransition[T any](old, next T, opts ...Option) error {
+	if val, ok := any(next).(TransitionValidatable[T]); ok {
+		return val.ValidateTransition(old)
+	}
+	return WalkTransition(old, next, opts...)
+}
+
+// WalkTransition walks old and next in parallel and calls ValidateTransition
+// of the descendants of next with the matching descendants of old.
+// Slice elements are matched by index and map values by key,
+// the descendants that exist only in one of the values are skipped.
+func WalkTransition[T any](old, next T, opts ...Option) error {
+	w := newWalker(context.Background(), newConfig(opts))
+	return w.walkTransition(toReflectValue(old), toReflectValue(next), false)
+}
+
+func AllTransitions[T any](validators ...TransitionValidator[T]) TransitionValidator[T] {
+	return func(old, next T) error {
+		for _, f := range validators {
+			if err := f(old, next); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func TransitionField[T, F any](name string, get func(T) F, validators ...TransitionValidator[F]) TransitionValidator[T] {
+	validate := AllTransitions(validators...)
+	elem := fieldElemOf[T](name)
+	return func(old, next T) error {
+		return withPath(validate(get(old), get(next)), elem)
+	}
+}
+
+func Immutable[T any, F comparable](field string, get func(T) F) TransitionValidator[T] {
+	return TransitionField(field, get, func(old, next F) error {
+		if old == next {
+			return nil
+		}
+		return failed("Immutable", next, map[string]any{"old": old}, fmt.Sprintf("validol.Immutable(%+v)(%+v)", old, next))
+	})
+}
+
+// AllowedTransitions allows to change a state only to the listed states,
+// keeping the state is always allowed.
+func AllowedTransitions[S comparable](allowed map[S][]S) TransitionValidator[S] {
+	return func(old, next S) error {
+		if old == next {
+			return nil
+		}
+		for _, s := range allowed[old] {
+			if s == next {
+				return nil
+			}
+		}
+		return failed("AllowedTransitions", next, map[string]any{"old": old, "vals": allowed[old]},
+			fmt.Sprintf("validol.AllowedTransitions(%+v)(%+v)", old, next))
+	}
+}
+
+//nolint:cyclop
+func (w *walker) walkTransition(old, next reflect.Value, validateItself bool) error {
+	if isNil(old) || isNil(next) || old.Type() != next.Type() || !mayTransition(next.Type()) {
+		return nil
+	}
+	if ref, ok := referenceOf(next); ok {
+		if _, ok := w.ancestors[ref]; ok {
+			return nil
+		}
+		if w.ancestors == nil {
+			w.ancestors = make(map[reference]struct{})
+		}
+		w.ancestors[ref] = struct{}{}
+		defer delete(w.ancestors, ref)
+	}
+	if validateItself && next.CanInterface() {
+		if method, ok := transitionMethod(next.Type()); ok {
+			err, _ := method.Func.Call([]reflect.Value{next, old})[0].Interface().(error)
+			return err
+		}
+	}
+
+	switch next.Kind() {
+	case reflect.Pointer:
+		return w.walkTransition(old.Elem(), next.Elem(), validateItself)
+	case reflect.Interface:
+		return w.walkTransition(old.Elem(), next.Elem(), true)
+	case reflect.Array, reflect.Slice, reflect.Map, reflect.Struct:
+		if err := w.enter(); err != nil {
+			return err
+		}
+		defer w.leave()
+		return w.walkTransitionChildren(old, next)
+	default:
+		return nil
+	}
+}
+
+func (w *walker) walkTransitionChildren(old, next reflect.Value) error {
+	errs := w.newCollector()
+	switch next.Kind() {
+	case reflect.Array, reflect.Slice:
+		for i := range min(old.Len(), next.Len()) {
+			if errs.add(w.walkTransition(old.Index(i), next.Index(i), true), indexElem(i)) {
+				break
+			}
+		}
+	case reflect.Map:
+		for _, key := range sortedMapKeys(next) {
+			prev := old.MapIndex(key)
+			if !prev.IsValid() {
+				continue
+			}
+			if errs.add(w.walkTransition(prev, next.MapIndex(key), true), mapValueElem(keyOf(key))) {
+				break
+			}
+		}
+	case reflect.Struct:
+		for i := range next.NumField() {
+			if !next.Type().Field(i).IsExported() {
+				continue
+			}
+			err := w.walkTransition(old.Field(i), next.Field(i), true)
+			if errs.add(err, structFieldElem(next.Type().Field(i))) {
+				break
+			}
+		}
+	default:
+	}
+	return errs.err()
+}
+
+// transitionMethod returns the ValidateTransition(old T) error method of T.
+func transitionMethod(typ reflect.Type) (reflect.Method, bool) {
+	method, ok := typ.MethodByName("ValidateTransition")
+	if !ok || typ.Kind() == reflect.Interface {
+		return method, false
+	}
+	mt := method.Type
+	ok = mt.NumIn() == 2 && mt.In(1) == typ && mt.NumOut() == 1 && mt.Out(0) == errorType
+	return method, ok
+}
+
+var errorType = reflect.TypeFor[error]()
+
+var transitionTypes sync.Map // map[reflect.Type]bool
+
+// mayTransition reports whether values of typ may hold a TransitionValidatable.
+func mayTransition(typ reflect.Type) bool {
+	if ok, found := transitionTypes.Load(typ); found {
+		return ok.(bool) //nolint:forcetypeassert // the cache holds only bool
+	}
+	ok := mayTransitionVisiting(typ, map[reflect.Type]bool{})
+	transitionTypes.Store(typ, ok)
+	return ok
+}
+
+func mayTransitionVisiting(typ reflect.Type, visiting map[reflect.Type]bool) bool {
+	if visiting[typ] {
+		return false
+	}
+	visiting[typ] = true
+	defer delete(visiting, typ)
+
+	if _, ok := transitionMethod(typ); ok || typ.Kind() == reflect.Interface {
+		return true
+	}
+	switch typ.Kind() {
+	case reflect.Pointer, reflect.Array, reflect.Slice, reflect.Map:
+		return mayTransitionVisiting(typ.Elem(), visiting)
+	case reflect.Struct:
+		for i := range typ.NumField() {
+			sf := typ.Field(i)
+			if sf.IsExported() && mayTransitionVisiting(sf.Type, visiting) {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
@@ -0,0 +1,118 @@
+package validol_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	vd "github.com/cospectrum/validol"
+	"github.com/stretchr/testify/assert"
+)
+
+type status string
+
+const (
+	pending  status = "pending"
+	active   status = "active"
+	canceled status = "canceled"
+)
+
+type balance int
+
+var errOverdraw = errors.New("balance may decrease by at most 100")
+
+func (b balance) ValidateTransition(old balance) error {
+	if old-b > 100 {
+		return errOverdraw
+	}
+	return nil
+}
+
+type wallet struct {
+	CreatedAt time.Time
+	Status    status
+	Balance   balance
+	Pockets   map[string]*balance
+	History   []balance
+}
+
+var validateWalletTransition = vd.AllTransitions(
+	vd.Immutable("CreatedAt", func(w wallet) time.Time { return w.CreatedAt }),
+	vd.TransitionField("Status", func(w wallet) status { return w.Status }, vd.AllowedTransitions(map[status][]status{
+		pending: {active, canceled},
+		active:  {canceled},
+	})),
+	func(old, next wallet) error { return vd.WalkTransition(old, next) },
+)
+
+func (w wallet) ValidateTransition(old wallet) error {
+	return validateWalletTransition(old, w)
+}
+
+func TestTransitionValidators(t *testing.T) {
+	t.Parallel()
+
+	allowed := vd.AllowedTransitions(map[status][]status{pending: {active}})
+	assert.NoError(t, allowed(pending, active))
+	assert.NoError(t, allowed(active, active))
+	err := allowed(active, pending)
+	verr := asValidationError(t, err)
+	assert.Equal(t, "AllowedTransitions", verr.Rule)
+	assert.Equal(t, map[string]any{"old": active, "vals": []status(nil)}, verr.Params)
+	assert.Equal(t, "validol.AllowedTransitions(active)(pending) failed", err.Error())
+
+	now := time.Now()
+	immutable := vd.Immutable("CreatedAt", func(w wallet) time.Time { return w.CreatedAt })
+	assert.NoError(t, immutable(wallet{CreatedAt: now}, wallet{CreatedAt: now}))
+	verr = asValidationError(t, immutable(wallet{CreatedAt: now}, wallet{}))
+	assert.Equal(t, "CreatedAt", verr.Path.String())
+	assert.Equal(t, "Immutable", verr.Rule)
+}
+
+func TestValidateTransition(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	old := wallet{CreatedAt: now, Status: pending, Balance: 500, History: []balance{300}}
+	next := old
+	next.Status = active
+	next.Balance = 400
+	assert.NoError(t, vd.ValidateTransition(old, next))
+
+	assert.NoError(t, vd.ValidateTransition(next, next))
+	verr := asValidationError(t, vd.ValidateTransition(next, old))
+	assert.Equal(t, "Status", verr.Path.String())
+
+	next.CreatedAt = now.Add(time.Hour)
+	verr = asValidationError(t, vd.ValidateTransition(old, next))
+	assert.Equal(t, "CreatedAt", verr.Path.String())
+	next.CreatedAt = now
+
+	next.Balance = 300
+	err := vd.ValidateTransition(old, next)
+	assert.ErrorIs(t, err, errOverdraw)
+	assert.Equal(t, "Balance", asValidationError(t, err).Path.String())
+	next.Balance = 500
+
+	one, zero := balance(1000), balance(0)
+	old.Pockets = map[string]*balance{"a": &one, "b": &one}
+	next.Pockets = map[string]*balance{"a": &one, "b": &zero, "c": &zero}
+	next.History = []balance{100, 0}
+	errs := vd.Errors(vd.WalkTransition(old, next, vd.WithCollectAll()))
+	paths := make([]string, 0, len(errs))
+	for _, verr := range errs {
+		paths = append(paths, verr.Path.String())
+	}
+	assert.Equal(t, []string{`Pockets["b"]`, "History[0]"}, paths)
+
+	olds := []wallet{old, {}}
+	nexts := []wallet{next, {Status: active}, {}}
+	verr = asValidationError(t, vd.ValidateTransition(olds, nexts))
+	assert.Equal(t, `[0].Pockets["b"]`, verr.Path.String())
+	olds[0] = next
+	verr = asValidationError(t, vd.ValidateTransition(olds, nexts))
+	assert.Equal(t, "[1].Status", verr.Path.String())
+
+	assert.NoError(t, vd.ValidateTransition(&old, nil))
+	assert.NoError(t, vd.ValidateTransition(1, 2))
+}
@@ -1,6 +1,10 @@
 package validol
 
-import "reflect"
+import (
+	"cmp"
+	"reflect"
+	"slices"
+)
 
 func toReflectValue[T any](t T) reflect.Value {
 	if val, ok := any(t).(reflect.Value); ok {
@@ -9,6 +13,13 @@ func toReflectValue[T any](t T) reflect.Value {
 	return reflect.ValueOf(&t).Elem()
 }
 
+func keyOf(key reflect.Value) any {
+	if key.CanInterface() {
+		return key.Interface()
+	}
+	return key.String()
+}
+
 func lenOf[T any](t T) int {
 	return reflect.ValueOf(t).Len()
 }
@@ -35,3 +46,97 @@ func isEmpty[T any](t T) bool {
 		return val.IsZero()
 	}
 }
+
+func isIntKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	default:
+		return false
+	}
+}
+
+func isUintKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	default:
+		return false
+	}
+}
+
+func isFloatKind(kind reflect.Kind) bool {
+	return kind == reflect.Float32 || kind == reflect.Float64
+}
+
+func isOrderedKind(kind reflect.Kind) bool {
+	return isIntKind(kind) || isUintKind(kind) || isFloatKind(kind) || kind == reflect.String
+}
+
+func sortedMapKeys(m reflect.Value) []reflect.Value {
+	keys := m.MapKeys()
+	slices.SortFunc(keys, compareValues)
+	return keys
+}
+
+// compareValues defines a total order over values of comparable kinds,
+// similar to the one used by fmt to print maps.
+//
+//nolint:cyclop
+func compareValues(a, b reflect.Value) int {
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(a.Int(), b.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return cmp.Compare(a.Uint(), b.Uint())
+	case reflect.String:
+		return cmp.Compare(a.String(), b.String())
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(a.Float(), b.Float())
+	case reflect.Complex64, reflect.Complex128:
+		if c := cmp.Compare(real(a.Complex()), real(b.Complex())); c != 0 {
+			return c
+		}
+		return cmp.Compare(imag(a.Complex()), imag(b.Complex()))
+	case reflect.Bool:
+		return compareBools(a.Bool(), b.Bool())
+	case reflect.Pointer, reflect.UnsafePointer, reflect.Chan:
+		return cmp.Compare(a.Pointer(), b.Pointer())
+	case reflect.Struct:
+		for i := range a.NumField() {
+			if c := compareValues(a.Field(i), b.Field(i)); c != 0 {
+				return c
+			}
+		}
+		return 0
+	case reflect.Array:
+		for i := range a.Len() {
+			if c := compareValues(a.Index(i), b.Index(i)); c != 0 {
+				return c
+			}
+		}
+		return 0
+	case reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			return compareBools(!a.IsNil(), !b.IsNil())
+		}
+		ta, tb := a.Elem().Type(), b.Elem().Type()
+		if ta != tb {
+			return cmp.Compare(ta.String(), tb.String())
+		}
+		return compareValues(a.Elem(), b.Elem())
+	default:
+		return 0
+	}
+}
+
+func compareBools(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return 1
+	default:
+		return -1
+	}
+}
@@ -0,0 +1,217 @@
+package validol
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Validated holds a value of T that passed Validate with WithTags.
+// It validates the value when it is decoded from JSON or text,
+// so a decoded Validated[T] is always valid.
+type Validated[T any] struct {
+	value T
+	// failed is the error of the last decode, it locates the value inside an outer value
+	failed *decodeError
+}
+
+// NewValidated validates t with WithTags and opts and wraps it.
+func NewValidated[T any](t T, opts ...Option) (Validated[T], error) {
+	if err := ValidateWith(t, append([]Option{WithTags()}, opts...)...); err != nil {
+		return Validated[T]{}, err
+	}
+	return Validated[T]{value: t}, nil
+}
+
+func (v Validated[T]) Get() T {
+	return v.value
+}
+
+var (
+	_ json.Unmarshaler         = &Validated[int]{}
+	_ json.Marshaler           = Validated[int]{}
+	_ encoding.TextUnmarshaler = &Validated[int]{}
+	_ encoding.TextMarshaler   = Validated[int]{}
+)
+
+// UnmarshalJSON decodes T and validates it with WithTags and WithCollectAll.
+// The paths of the errors are relative to the outermost Validated being decoded,
+// decode the outer value with UnmarshalJSON to get them relative to it.
+func (v *Validated[T]) UnmarshalJSON(data []byte) error {
+	var t T
+	if err := json.Unmarshal(data, &t); err != nil {
+		return v.fail(located(&t, err))
+	}
+	return v.set(t)
+}
+
+func (v Validated[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.value)
+}
+
+// UnmarshalText decodes T with its UnmarshalText method,
+// or parses it if it is a string, a bool or a number, and validates it.
+func (v *Validated[T]) UnmarshalText(text []byte) error {
+	var t T
+	if err := unmarshalText(&t, text); err != nil {
+		return v.fail(located(&t, err))
+	}
+	return v.set(t)
+}
+
+func (v Validated[T]) MarshalText() ([]byte, error) {
+	return marshalText(v.value)
+}
+
+func (v *Validated[T]) set(t T) error {
+	if err := ValidateWith(t, WithTags(), WithCollectAll()); err != nil {
+		return v.fail(err)
+	}
+	v.value = t
+	v.failed = nil
+	return nil
+}
+
+func (v *Validated[T]) fail(err error) error {
+	v.failed = &decodeError{err: err}
+	return v.failed
+}
+
+func (v Validated[T]) decodeFailure() *decodeError {
+	return v.failed
+}
+
+func unmarshalText[T any](t *T, text []byte) error {
+	if u, ok := any(t).(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText(text)
+	}
+	parsed, err := parseParam(reflect.TypeFor[T](), string(text))
+	if err != nil {
+		return fmt.Errorf("validol: %w", err)
+	}
+	*t = parsed.(T) //nolint:forcetypeassert // parseParam converts to T
+	return nil
+}
+
+func marshalText[T any](t T) ([]byte, error) {
+	if m, ok := any(t).(encoding.TextMarshaler); ok {
+		return m.MarshalText()
+	}
+	val := reflect.ValueOf(&t).Elem()
+	switch kind := val.Kind(); {
+	case kind == reflect.String, kind == reflect.Bool,
+		isIntKind(kind), isUintKind(kind), isFloatKind(kind):
+		return []byte(fmt.Sprint(t)), nil
+	default:
+		return nil, fmt.Errorf("validol: %s can not be marshaled as text", val.Type())
+	}
+}
+
+// UnmarshalJSON is json.Unmarshal that reports a Validated or a Refined that failed
+// to decode at its path inside v, json.Unmarshal reports it relative to the failed value.
+func UnmarshalJSON(data []byte, v any) error {
+	return located(v, json.Unmarshal(data, v))
+}
+
+// DecodeJSON is UnmarshalJSON for the next value of dec.
+func DecodeJSON(dec *json.Decoder, v any) error {
+	return located(v, dec.Decode(v))
+}
+
+// decodeError is an error of a Validated or a Refined being decoded,
+// the failed value keeps it to be located inside the outer value.
+type decodeError struct {
+	err error
+}
+
+func (e *decodeError) Error() string {
+	return e.err.Error()
+}
+
+func (e *decodeError) Unwrap() error {
+	return e.err
+}
+
+// decodable is implemented by Validated and Refined.
+type decodable interface {
+	// decodeFailure returns the error of the last decode, or nil.
+	decodeFailure() *decodeError
+}
+
+var decodableType = reflect.TypeFor[decodable]()
+
+// located returns err of decoding into dst, the path of the error of a Validated
+// or a Refined inside dst gets its location.
+func located(dst any, err error) error {
+	var nested *decodeError
+	if !errors.As(err, &nested) {
+		return err
+	}
+	path, ok := locate(reflect.ValueOf(dst), nested, map[uintptr]struct{}{})
+	if !ok {
+		return err
+	}
+	err = nested.err
+	for i := len(path) - 1; i >= 0; i-- {
+		err = withPath(err, path[i])
+	}
+	return err
+}
+
+// locate returns the path of the value that failed with e inside val.
+// It re-walks the decoded value, since the decoder may move the failed value,
+// e.g. by growing a slice, seen are the visited pointers.
+func locate(val reflect.Value, e *decodeError, seen map[uintptr]struct{}) (Path, bool) {
+	if !val.IsValid() {
+		return nil, false
+	}
+	if val.Kind() == reflect.Struct && val.Type().Implements(decodableType) && val.CanInterface() {
+		if d, ok := val.Interface().(decodable); ok && d.decodeFailure() == e {
+			return nil, true
+		}
+	}
+	switch val.Kind() {
+	case reflect.Pointer:
+		if val.IsNil() {
+			return nil, false
+		}
+		if _, ok := seen[val.Pointer()]; ok {
+			return nil, false
+		}
+		seen[val.Pointer()] = struct{}{}
+		return locate(val.Elem(), e, seen)
+	case reflect.Interface:
+		return locate(val.Elem(), e, seen)
+	case reflect.Array, reflect.Slice:
+		for i := range val.Len() {
+			if path, ok := locate(val.Index(i), e, seen); ok {
+				return append(Path{indexElem(i)}, path...), true
+			}
+		}
+	case reflect.Map:
+		iter := val.MapRange()
+		for iter.Next() {
+			if path, ok := locate(iter.Value(), e, seen); ok {
+				return append(Path{mapValueElem(keyOf(iter.Key()))}, path...), true
+			}
+		}
+	case reflect.Struct:
+		if val.Type().Implements(decodableType) {
+			// the fields of a Validated are not a part of the path
+			return nil, false
+		}
+		for i := range val.NumField() {
+			sf := val.Type().Field(i)
+			if !sf.IsExported() {
+				continue
+			}
+			if path, ok := locate(val.Field(i), e, seen); ok {
+				return append(Path{structFieldElem(sf)}, path...), true
+			}
+		}
+	default:
+	}
+	return nil, false
+}
@@ -0,0 +1,127 @@
+package validol_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	vd "github.com/cospectrum/validol"
+	"github.com/stretchr/testify/assert"
+)
+
+type signupForm struct {
+	Email email `json:"email"`
+	Age   int   `json:"age"   validate:"gte=18"`
+}
+
+type team struct {
+	Name    string                       `json:"name"`
+	Lead    *vd.Validated[signupForm]    `json:"lead"`
+	Members []vd.Validated[signupForm]   `json:"members"`
+	ByName  map[string]vd.Validated[qty] `json:"by_name"`
+}
+
+func pointers(err error) []string {
+	var out []string
+	for _, verr := range vd.Errors(err) {
+		out = append(out, verr.Path.JSONPointer())
+	}
+	return out
+}
+
+func TestValidatedJSON(t *testing.T) {
+	t.Parallel()
+
+	var form vd.Validated[signupForm]
+	assert.NoError(t, json.Unmarshal([]byte(`{"email": "a@b.c", "age": 20}`), &form))
+	assert.Equal(t, signupForm{Email: "a@b.c", Age: 20}, form.Get())
+	data, err := json.Marshal(form)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"email": "a@b.c", "age": 20}`, string(data))
+
+	err = json.Unmarshal([]byte(`{"email": "x", "age": 1}`), &form)
+	assert.Equal(t, []string{"/email", "/age"}, pointers(err))
+	assert.Equal(t, signupForm{Email: "a@b.c", Age: 20}, form.Get())
+	assert.Equal(t, "Gte", asValidationError(t, vd.Errors(err)[1]).Rule)
+
+	var tm vd.Validated[team]
+	assert.NoError(t, json.Unmarshal([]byte(`{"name": "a", "members": [{"email": "a@b.c", "age": 20}]}`), &tm))
+	assert.Len(t, tm.Get().Members, 1)
+
+	err = json.Unmarshal([]byte(`{"members": [{"email": "a@b.c", "age": 20}, {"email": "x", "age": 20}]}`), &tm)
+	assert.Equal(t, []string{"/members/1/email"}, pointers(err))
+	err = json.Unmarshal([]byte(`{"lead": {"email": "a@b.c", "age": 2}}`), &tm)
+	assert.Equal(t, []string{"/lead/age"}, pointers(err))
+	assert.Equal(t, "Lead.Age: validol.Gte(18)(2) failed", err.Error())
+	err = json.Unmarshal([]byte(`{"members": [{"email": "x", "age": 20}, {"email": "a@b.c", "age": 20}, {"email": "a@b.c", "age": 20}, {"email": "a@b.c", "age": 20}]}`), &tm)
+	assert.Equal(t, []string{"/members/0/email"}, pointers(err))
+	err = json.Unmarshal([]byte(`{"by_name": {"a": 0}}`), &tm)
+	// encoding/json before v2 drops the failed map value, so it is reported relative to itself
+	assert.Len(t, pointers(err), 1)
+
+	var plain team
+	err = json.Unmarshal([]byte(`{"lead": {"email": "a@b.c", "age": 2}}`), &plain)
+	assert.Equal(t, []string{"/age"}, pointers(err))
+	err = vd.UnmarshalJSON([]byte(`{"lead": {"email": "a@b.c", "age": 2}}`), &plain)
+	assert.Equal(t, []string{"/lead/age"}, pointers(err))
+	err = vd.UnmarshalJSON([]byte(`{"members": [{"email": "x", "age": 20}, {"email": "a@b.c", "age": 20}]}`), &plain)
+	assert.Equal(t, []string{"/members/0/email"}, pointers(err))
+	assert.NoError(t, vd.UnmarshalJSON([]byte(`{"name": "a"}`), &plain))
+	assert.Error(t, vd.UnmarshalJSON([]byte(`{`), &plain))
+
+	var signup struct {
+		User struct {
+			Email vd.Validated[email] `json:"email"`
+		} `json:"user"`
+	}
+	err = vd.DecodeJSON(json.NewDecoder(strings.NewReader(`{"user": {"email": "bad"}}`)), &signup)
+	assert.Equal(t, []string{"/user/email"}, pointers(err))
+
+	err = json.Unmarshal([]byte(`{"age": "1"}`), &form)
+	assert.Error(t, err)
+	assert.Empty(t, vd.Errors(err)[0].Path)
+}
+
+func TestValidatedText(t *testing.T) {
+	t.Parallel()
+
+	var q vd.Validated[qty]
+	assert.NoError(t, q.UnmarshalText([]byte("5")))
+	assert.Equal(t, qty(5), q.Get())
+	assert.Error(t, q.UnmarshalText([]byte("0")))
+	assert.Error(t, q.UnmarshalText([]byte("x")))
+	assert.Equal(t, qty(5), q.Get())
+	text, err := q.MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "5", string(text))
+
+	var stock map[vd.Validated[sku]]int
+	assert.NoError(t, json.Unmarshal([]byte(`{"sku-1": 1}`), &stock))
+	data, err := json.Marshal(stock)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"sku-1": 1}`, string(data))
+	assert.Error(t, json.Unmarshal([]byte(`{"1": 1}`), &stock))
+
+	var ts vd.Validated[time.Time]
+	assert.NoError(t, ts.UnmarshalText([]byte("2024-01-02T03:04:05Z")))
+	text, err = ts.MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-01-02T03:04:05Z", string(text))
+	assert.Error(t, ts.UnmarshalText([]byte("tomorrow")))
+
+	_, err = vd.Validated[[]int]{}.MarshalText()
+	assert.Error(t, err)
+}
+
+func TestNewValidated(t *testing.T) {
+	t.Parallel()
+
+	q, err := vd.NewValidated(qty(1))
+	assert.NoError(t, err)
+	assert.Equal(t, qty(1), q.Get())
+	_, err = vd.NewValidated(qty(0))
+	assert.Equal(t, "Gt", asValidationError(t, err).Rule)
+	_, err = vd.NewValidated(signupForm{}, vd.WithCollectAll())
+	assert.Len(t, vd.Errors(err), 2)
+}
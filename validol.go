@@ -2,6 +2,7 @@ package validol
 
 import (
 	"cmp"
+	"context"
 	"fmt"
 	"strings"
 )
@@ -13,10 +14,24 @@ type Validatable interface {
 }
 
 func Validate[T any](t T) error {
-	if val, ok := any(t).(Validatable); ok {
+	return ValidateWith(t)
+}
+
+// ValidateWith is like Validate, configured by opts.
+func ValidateWith[T any](t T, opts ...Option) error {
+	cfg := newConfig(opts)
+	if cfg.partial != nil {
+		// the methods would validate the fields that are not provided
+		return WalkWith(t, opts...)
+	}
+	switch val := any(t).(type) {
+	case Validatable:
 		return val.Validate()
+	case ValidatableCtx:
+		return val.ValidateCtx(cfg.withGroups(context.Background()))
+	default:
+		return WalkWith(t, opts...)
 	}
-	return Walk(t)
 }
 
 func OneOf[T comparable](vals ...T) Validator[T] {
@@ -26,7 +41,7 @@ func OneOf[T comparable](vals ...T) Validator[T] {
 				return nil
 			}
 		}
-		return failed(fmt.Sprintf("validol.OneOf(%s)(%+v)", fmtVarargs(vals), t))
+		return failed("OneOf", t, map[string]any{"vals": vals}, fmt.Sprintf("validol.OneOf(%s)(%+v)", fmtVarargs(vals), t))
 	}
 }
 
@@ -67,18 +82,23 @@ func True(b bool) error {
 	if b {
 		return nil
 	}
-	return failed(fmt.Sprintf("validol.True(%v)", b))
+	return failed("True", b, nil, fmt.Sprintf("validol.True(%v)", b))
 }
 
 func False(b bool) error {
 	if !b {
 		return nil
 	}
-	return failed(fmt.Sprintf("validol.False(%v)", b))
+	return failed("False", b, nil, fmt.Sprintf("validol.False(%v)", b))
 }
 
 func Walk[T any](t T) error {
-	return walkDescendants(t)
+	return WalkWith(t)
+}
+
+// WalkWith is like Walk, configured by opts.
+func WalkWith[T any](t T, opts ...Option) error {
+	return newWalker(context.Background(), newConfig(opts)).walkDescendants(toReflectValue(t))
 }
 
 var _ Validator[any] = Nil
@@ -87,7 +107,7 @@ func Nil[T any](t T) error {
 	if isNil(t) {
 		return nil
 	}
-	return failed(fmt.Sprintf("validol.Nil(%+v)", t))
+	return failed("Nil", t, nil, fmt.Sprintf("validol.Nil(%+v)", t))
 }
 
 var _ Validator[any] = NotNil
@@ -97,7 +117,7 @@ func NotNil[T any](t T) error {
 	if notNil {
 		return nil
 	}
-	return failed(fmt.Sprintf("validol.NotNil(%+v)", t))
+	return failed("NotNil", t, nil, fmt.Sprintf("validol.NotNil(%+v)", t))
 }
 
 var _ Validator[any] = Empty
@@ -106,7 +126,7 @@ func Empty[T any](t T) error {
 	if isEmpty(t) {
 		return nil
 	}
-	return failed(fmt.Sprintf("validol.Empty(%+v)", t))
+	return failed("Empty", t, nil, fmt.Sprintf("validol.Empty(%+v)", t))
 }
 
 var _ Validator[any] = Required
@@ -116,7 +136,7 @@ func Required[T any](t T) error {
 	if notEmpty {
 		return nil
 	}
-	return failed(fmt.Sprintf("validol.Required(%+v)", t))
+	return failed("Required", t, nil, fmt.Sprintf("validol.Required(%+v)", t))
 }
 
 func Not[T any](fn Validator[T]) Validator[T] {
@@ -124,7 +144,7 @@ func Not[T any](fn Validator[T]) Validator[T] {
 		if err := fn(t); err != nil {
 			return nil //nolint:nilerr
 		}
-		return failed(fmt.Sprintf("validol.Not(...)(%+v)", t))
+		return failed("Not", t, nil, fmt.Sprintf("validol.Not(...)(%+v)", t))
 	}
 }
 
@@ -132,7 +152,7 @@ func Gt[T cmp.Ordered](val T) Validator[T] {
 	return func(t T) error {
 		ok := t > val
 		if !ok {
-			return failed(fmt.Sprintf("validol.Gt(%+v)(%+v)", val, t))
+			return failed("Gt", t, map[string]any{"val": val}, fmt.Sprintf("validol.Gt(%+v)(%+v)", val, t))
 		}
 		return nil
 	}
@@ -142,7 +162,7 @@ func Gte[T cmp.Ordered](val T) Validator[T] {
 	return func(t T) error {
 		ok := t >= val
 		if !ok {
-			return failed(fmt.Sprintf("validol.Gte(%+v)(%+v)", val, t))
+			return failed("Gte", t, map[string]any{"val": val}, fmt.Sprintf("validol.Gte(%+v)(%+v)", val, t))
 		}
 		return nil
 	}
@@ -152,7 +172,7 @@ func Lt[T cmp.Ordered](val T) Validator[T] {
 	return func(t T) error {
 		ok := t < val
 		if !ok {
-			return failed(fmt.Sprintf("validol.Lt(%+v)(%+v)", val, t))
+			return failed("Lt", t, map[string]any{"val": val}, fmt.Sprintf("validol.Lt(%+v)(%+v)", val, t))
 		}
 		return nil
 	}
@@ -162,7 +182,7 @@ func Lte[T cmp.Ordered](val T) Validator[T] {
 	return func(t T) error {
 		ok := t <= val
 		if !ok {
-			return failed(fmt.Sprintf("validol.Lte(%+v)(%+v)", val, t))
+			return failed("Lte", t, map[string]any{"val": val}, fmt.Sprintf("validol.Lte(%+v)(%+v)", val, t))
 		}
 		return nil
 	}
@@ -172,7 +192,7 @@ func Eq[T comparable](val T) Validator[T] {
 	return func(t T) error {
 		ok := t == val
 		if !ok {
-			return failed(fmt.Sprintf("validol.Eq(%+v)(%+v)", val, t))
+			return failed("Eq", t, map[string]any{"val": val}, fmt.Sprintf("validol.Eq(%+v)(%+v)", val, t))
 		}
 		return nil
 	}
@@ -182,18 +202,22 @@ func Ne[T comparable](val T) Validator[T] {
 	return func(t T) error {
 		ok := t != val
 		if !ok {
-			return failed(fmt.Sprintf("validol.Ne(%+v)(%+v)", val, t))
+			return failed("Ne", t, map[string]any{"val": val}, fmt.Sprintf("validol.Ne(%+v)(%+v)", val, t))
 		}
 		return nil
 	}
 }
 
+// Len validates the length of t, the codes of the errors get the "len_" prefix.
 func Len[T any](validateLen Validator[int]) Validator[T] {
 	return func(t T) error {
-		if err := validateLen(lenOf(t)); err != nil {
-			return err
+		err := validateLen(lenOf(t))
+		if verr, ok := err.(*ValidationError); ok && verr.Code != "" { //nolint:errorlint // only direct errors are renamed
+			out := *verr
+			out.Code = "len_" + verr.Code
+			return &out
 		}
-		return nil
+		return err
 	}
 }
 
@@ -202,7 +226,7 @@ func StartsWith(prefix string) Validator[string] {
 		if strings.HasPrefix(s, prefix) {
 			return nil
 		}
-		return failed(fmt.Sprintf("validol.StartsWith(%q)(%q)", prefix, s))
+		return failed("StartsWith", s, map[string]any{"prefix": prefix}, fmt.Sprintf("validol.StartsWith(%q)(%q)", prefix, s))
 	}
 }
 
@@ -211,7 +235,7 @@ func EndsWith(suffix string) Validator[string] {
 		if strings.HasSuffix(s, suffix) {
 			return nil
 		}
-		return failed(fmt.Sprintf("validol.EndsWith(%q)(%q)", suffix, s))
+		return failed("EndsWith", s, map[string]any{"suffix": suffix}, fmt.Sprintf("validol.EndsWith(%q)(%q)", suffix, s))
 	}
 }
 
@@ -220,7 +244,7 @@ func Contains(substr string) Validator[string] {
 		if strings.Contains(s, substr) {
 			return nil
 		}
-		return failed(fmt.Sprintf("validol.Contains(%q)(%q)", substr, s))
+		return failed("Contains", s, map[string]any{"substr": substr}, fmt.Sprintf("validol.Contains(%q)(%q)", substr, s))
 	}
 }
 
@@ -229,6 +253,6 @@ func ContainsRune(r rune) Validator[string] {
 		if strings.ContainsRune(s, r) {
 			return nil
 		}
-		return failed(fmt.Sprintf("validol.ContainsRune(%q)(%q)", r, s))
+		return failed("ContainsRune", s, map[string]any{"rune": string(r)}, fmt.Sprintf("validol.ContainsRune(%q)(%q)", r, s))
 	}
 }
@@ -0,0 +1,215 @@
+// Package validolconfig loads and validates configuration from environment variables and flags.
+package validolconfig
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"reflect"
+
+	vd "github.com/cospectrum/validol"
+)
+
+// Error is an invalid or missing setting.
+type Error struct {
+	// Name is the environment variable, or the flag with a leading dash
+	// if the value was set by the flag or the field has no variable.
+	Name string
+	// Path is the path of the field in the configuration struct.
+	Path vd.Path
+	// Err is the parse error or the *validol.ValidationError,
+	// the path of the latter is relative to the field.
+	Err error
+}
+
+var _ error = &Error{}
+
+func (e *Error) Error() string {
+	return e.Name + ": " + e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+type Option func(*config)
+
+type config struct {
+	lookupEnv    func(string) (string, bool)
+	flags        *flag.FlagSet
+	args         []string
+	validateOpts []vd.Option
+}
+
+func newConfig(opts []Option) config {
+	cfg := config{lookupEnv: os.LookupEnv, validateOpts: []vd.Option{vd.WithTags()}}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// WithLookupEnv reads the environment variables with fn instead of os.LookupEnv.
+func WithLookupEnv(fn func(string) (string, bool)) Option {
+	return func(c *config) {
+		c.lookupEnv = fn
+	}
+}
+
+// WithFlags defines the flags of the fields tagged with `flag:"name"` on fs
+// and parses args, a set flag takes precedence over the environment variable.
+func WithFlags(fs *flag.FlagSet, args []string) Option {
+	return func(c *config) {
+		c.flags = fs
+		c.args = args
+	}
+}
+
+// WithValidateOptions passes opts to validol.ValidateWith after WithTags,
+// WithTagName("") disables the tag rules.
+func WithValidateOptions(opts ...vd.Option) Option {
+	return func(c *config) {
+		c.validateOpts = append(c.validateOpts, opts...)
+	}
+}
+
+// Load sets the fields of T tagged with `env:"NAME"`, `flag:"name"` and `default:"value"`
+// and validates the result with validol.ValidateWith, WithTags and WithCollectAll.
+// All the invalid and missing settings are reported at once as *Error,
+// a failed flag parse or an unsupported field type is returned as is.
+func Load[T any](opts ...Option) (T, error) {
+	var t T
+	cfg := newConfig(opts)
+	settings, err := settingsOf(reflect.TypeFor[T]())
+	if err != nil {
+		return t, err
+	}
+	flags, err := parseFlags(settings, cfg)
+	if err != nil {
+		return t, err
+	}
+
+	val := reflect.ValueOf(&t).Elem()
+	errs := make([][]error, len(settings)+1)
+	names := make([]string, len(settings))
+	failed := make([]bool, len(settings))
+	for i := range settings {
+		s := &settings[i]
+		raw, name, ok := lookup(s, flags[i], cfg)
+		if !ok {
+			continue
+		}
+		names[i] = name
+		if err := setValue(val.FieldByIndex(s.index), raw); err != nil {
+			errs[i] = append(errs[i], &Error{Name: name, Path: s.path, Err: err})
+			failed[i] = true
+		}
+	}
+
+	validateOpts := append([]vd.Option{vd.WithCollectAll()}, cfg.validateOpts...)
+	for _, verr := range vd.Errors(vd.ValidateWith(t, validateOpts...)) {
+		i := settingOf(settings, verr.Path)
+		if i < 0 {
+			errs[len(settings)] = append(errs[len(settings)], verr)
+			continue
+		}
+		if failed[i] {
+			// the field holds the zero value
+			continue
+		}
+		name := names[i]
+		if name == "" {
+			name = settings[i].name()
+		}
+		rel := *verr
+		rel.Path = verr.Path[len(settings[i].path):]
+		errs[i] = append(errs[i], &Error{Name: name, Path: settings[i].path, Err: &rel})
+	}
+
+	var all []error
+	for _, e := range errs {
+		all = append(all, e...)
+	}
+	return t, errors.Join(all...)
+}
+
+// lookup returns the raw value of s and the name of its source:
+// the flag, the environment variable or the default.
+func lookup(s *setting, fv *flagValue, cfg config) (string, string, bool) {
+	if fv != nil && fv.set {
+		return fv.value, "-" + s.flag, true
+	}
+	if s.env != "" {
+		if raw, ok := cfg.lookupEnv(s.env); ok {
+			return raw, s.env, true
+		}
+	}
+	if s.def != "" {
+		return s.def, s.name(), true
+	}
+	return "", "", false
+}
+
+// settingOf returns the index of the setting that holds the value at path, or -1.
+func settingOf(settings []setting, path vd.Path) int {
+	for i, s := range settings {
+		if len(path) < len(s.path) {
+			continue
+		}
+		match := true
+		for j, elem := range s.path {
+			if path[j].Kind != vd.PathField || path[j].Name != elem.Name {
+				match = false
+				break
+			}
+		}
+		if match {
+			return i
+		}
+	}
+	return -1
+}
+
+func parseFlags(settings []setting, cfg config) ([]*flagValue, error) {
+	flags := make([]*flagValue, len(settings))
+	if cfg.flags == nil {
+		return flags, nil
+	}
+	for i, s := range settings {
+		if s.flag == "" {
+			continue
+		}
+		flags[i] = &flagValue{value: s.def, isBool: s.typ.Kind() == reflect.Bool}
+		cfg.flags.Var(flags[i], s.flag, s.usage)
+	}
+	if err := cfg.flags.Parse(cfg.args); err != nil {
+		return nil, err
+	}
+	return flags, nil
+}
+
+// flagValue records the value of a flag, it is parsed by Load.
+type flagValue struct {
+	value  string
+	set    bool
+	isBool bool
+}
+
+var _ flag.Value = &flagValue{}
+
+func (f *flagValue) String() string {
+	if f == nil {
+		return ""
+	}
+	return f.value
+}
+
+func (f *flagValue) Set(s string) error {
+	f.value = s
+	f.set = true
+	return nil
+}
+
+func (f *flagValue) IsBoolFlag() bool {
+	return f.isBool
+}
@@ -0,0 +1,153 @@
+package validolconfig_test
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"testing"
+	"time"
+
+	vd "github.com/cospectrum/validol"
+	"github.com/cospectrum/validol/validolconfig"
+	"github.com/stretchr/testify/assert"
+)
+
+type dbConfig struct {
+	Host string `env:"HOST" flag:"host" validate:"required"     usage:"database host"`
+	Port int    `env:"PORT" default:"5432" validate:"gte=1,lte=65535"`
+}
+
+type appConfig struct {
+	Port     int           `env:"PORT"    flag:"port" default:"8080" validate:"gte=1,lte=65535" usage:"HTTP port"`
+	Debug    bool          `env:"DEBUG"   flag:"debug"`
+	Timeout  time.Duration `env:"TIMEOUT" default:"5s" validate:"gt=0"`
+	Origins  []string      `env:"ORIGINS" validate:"min_len=1"`
+	Level    *string       `flag:"level"  validate:"omitempty,one_of=debug info"`
+	DB       dbConfig      `env:"DB_"     flag:"db."`
+	Since    time.Time     `env:"SINCE"`
+	Untagged string
+}
+
+func envOf(vars map[string]string) validolconfig.Option {
+	return validolconfig.WithLookupEnv(func(name string) (string, bool) {
+		val, ok := vars[name]
+		return val, ok
+	})
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := validolconfig.Load[appConfig](envOf(map[string]string{
+		"DB_HOST": "db",
+		"ORIGINS": "a, b",
+		"SINCE":   "2024-01-02T03:04:05Z",
+	}))
+	assert.NoError(t, err)
+	assert.Equal(t, appConfig{
+		Port:    8080,
+		Timeout: 5 * time.Second,
+		Origins: []string{"a", "b"},
+		DB:      dbConfig{Host: "db", Port: 5432},
+		Since:   time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	}, cfg)
+
+	fs := flag.NewFlagSet("app", flag.ContinueOnError)
+	cfg, err = validolconfig.Load[appConfig](
+		envOf(map[string]string{"PORT": "1", "DB_HOST": "db", "ORIGINS": "a"}),
+		validolconfig.WithFlags(fs, []string{"-port", "9090", "-debug", "-db.host", "localhost", "-level=info", "arg"}),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, 9090, cfg.Port)
+	assert.True(t, cfg.Debug)
+	assert.Equal(t, "localhost", cfg.DB.Host)
+	assert.Equal(t, "info", *cfg.Level)
+	assert.Equal(t, []string{"arg"}, fs.Args())
+}
+
+func TestLoadErrors(t *testing.T) {
+	t.Parallel()
+
+	fs := flag.NewFlagSet("app", flag.ContinueOnError)
+	_, err := validolconfig.Load[appConfig](
+		envOf(map[string]string{"PORT": "0", "TIMEOUT": "soon", "DB_PORT": "x"}),
+		validolconfig.WithFlags(fs, []string{"-level", "trace"}),
+	)
+	assert.Equal(t, `PORT: validol.Gte(1)(0) failed
+TIMEOUT: time: invalid duration "soon"
+ORIGINS: validol.Gte(1)(0) failed
+-level: validol.OneOf(debug, info)(trace) failed
+DB_HOST: validol.Required() failed
+DB_PORT: strconv.ParseInt: parsing "x": invalid syntax`, err.Error())
+
+	var settingErr *validolconfig.Error
+	assert.True(t, errors.As(err, &settingErr))
+	assert.Equal(t, "PORT", settingErr.Name)
+	assert.Equal(t, "Port", settingErr.Path.String())
+	errs := vd.Errors(err)
+	assert.Equal(t, "Gte", errs[0].Rule)
+	assert.Empty(t, errs[0].Path)
+	assert.Equal(t, "Required", errs[4].Rule)
+
+	fs = flag.NewFlagSet("app", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	_, err = validolconfig.Load[appConfig](validolconfig.WithFlags(fs, []string{"-unknown"}))
+	assert.Error(t, err)
+	assert.Nil(t, vd.Errors(err)[0].Path)
+
+	_, err = validolconfig.Load[struct {
+		C chan int `env:"C"`
+	}]()
+	assert.ErrorContains(t, err, "unsupported type chan int")
+	_, err = validolconfig.Load[int]()
+	assert.ErrorContains(t, err, "int is not a struct")
+}
+
+type port struct{}
+
+func (port) Validate(n uint16) error {
+	return vd.Gte[uint16](1024)(n)
+}
+
+func TestLoadTypes(t *testing.T) {
+	t.Parallel()
+
+	type types struct {
+		IDs   []int64                       `env:"IDS"`
+		Ratio float32                       `env:"RATIO"`
+		Port  vd.Refined[uint16, port]      `env:"PORT"`
+		Limit *uint                         `env:"LIMIT"`
+		Name  vd.Validated[string]          `env:"NAME"`
+		Hosts []vd.Refined[string, nonHost] `env:"HOSTS" default:"a"`
+	}
+	cfg, err := validolconfig.Load[types](envOf(map[string]string{
+		"IDS":   "1,2",
+		"RATIO": "0.5",
+		"PORT":  "8080",
+		"LIMIT": "3",
+		"NAME":  "",
+	}))
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1, 2}, cfg.IDs)
+	assert.InDelta(t, 0.5, cfg.Ratio, 0)
+	assert.Equal(t, uint16(8080), cfg.Port.Get())
+	assert.Equal(t, uint(3), *cfg.Limit)
+	assert.Len(t, cfg.Hosts, 1)
+
+	_, err = validolconfig.Load[types](envOf(map[string]string{
+		"IDS":   "1,x",
+		"PORT":  "80",
+		"LIMIT": "-1",
+		"HOSTS": "a,localhost",
+	}))
+	assert.Equal(t, `IDS: strconv.ParseInt: parsing "x": invalid syntax
+PORT: validol.Gte(1024)(80) failed
+LIMIT: strconv.ParseUint: parsing "-1": invalid syntax
+HOSTS: validol.Ne(localhost)(localhost) failed`, err.Error())
+}
+
+type nonHost struct{}
+
+func (nonHost) Validate(s string) error {
+	return vd.Ne("localhost")(s)
+}
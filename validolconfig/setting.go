@@ -0,0 +1,176 @@
+package validolconfig
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	vd "github.com/cospectrum/validol"
+)
+
+var (
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+	durationType        = reflect.TypeFor[time.Duration]()
+)
+
+// setting is a struct field bound to an environment variable or a flag.
+type setting struct {
+	env   string
+	flag  string
+	def   string
+	usage string
+	rules string
+	typ   reflect.Type
+	index []int
+	path  vd.Path
+}
+
+// name returns the name of the variable, or of the flag if there is none.
+func (s *setting) name() string {
+	if s.env != "" {
+		return s.env
+	}
+	return "-" + s.flag
+}
+
+// settingsOf returns the settings of the struct typ. The `env` and `flag` tags
+// of a nested struct field are prefixes of the names of its fields.
+func settingsOf(typ reflect.Type) ([]setting, error) {
+	if typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("validolconfig: %s is not a struct", typ)
+	}
+	return appendSettings(nil, typ, setting{})
+}
+
+func appendSettings(out []setting, typ reflect.Type, parent setting) ([]setting, error) {
+	for i := range typ.NumField() {
+		sf := typ.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		env, hasEnv := sf.Tag.Lookup("env")
+		flagName, hasFlag := sf.Tag.Lookup("flag")
+		def, hasDef := sf.Tag.Lookup("default")
+		s := setting{
+			env:   parent.env + env,
+			flag:  parent.flag + flagName,
+			def:   def,
+			usage: sf.Tag.Get("usage"),
+			rules: sf.Tag.Get("validate"),
+			typ:   sf.Type,
+			index: append(append([]int(nil), parent.index...), i),
+			path:  append(append(vd.Path(nil), parent.path...), vd.PathElem{Kind: vd.PathField, Name: sf.Name, Tag: sf.Tag, Embedded: sf.Anonymous}),
+		}
+		if sf.Type.Kind() == reflect.Struct && !isText(sf.Type) {
+			var err error
+			if out, err = appendSettings(out, sf.Type, s); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if !hasEnv && !hasFlag && !hasDef {
+			continue
+		}
+		if !supported(sf.Type) {
+			return nil, fmt.Errorf("validolconfig: %s.%s: unsupported type %s", typ, sf.Name, sf.Type)
+		}
+		if !hasEnv {
+			s.env = ""
+		}
+		if !hasFlag {
+			s.flag = ""
+		}
+		out = append(out, s)
+	}
+	return out, nil
+}
+
+func isText(typ reflect.Type) bool {
+	return reflect.PointerTo(typ).Implements(textUnmarshalerType)
+}
+
+func supported(typ reflect.Type) bool {
+	if isText(typ) {
+		return true
+	}
+	switch typ.Kind() {
+	case reflect.Pointer, reflect.Slice:
+		return supported(typ.Elem())
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// setValue parses s into val, slices are comma-separated.
+//
+//nolint:cyclop
+func setValue(val reflect.Value, s string) error {
+	if isText(val.Type()) {
+		return val.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s)) //nolint:forcetypeassert // checked by isText
+	}
+	switch val.Kind() {
+	case reflect.Pointer:
+		ptr := reflect.New(val.Type().Elem())
+		if err := setValue(ptr.Elem(), s); err != nil {
+			return err
+		}
+		val.Set(ptr)
+	case reflect.Slice:
+		var parts []string
+		if s != "" {
+			parts = strings.Split(s, ",")
+		}
+		out := reflect.MakeSlice(val.Type(), len(parts), len(parts))
+		for i, part := range parts {
+			if err := setValue(out.Index(i), strings.TrimSpace(part)); err != nil {
+				return err
+			}
+		}
+		val.Set(out)
+	case reflect.String:
+		val.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		val.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if val.Type() == durationType {
+			d, err := time.ParseDuration(s)
+			if err != nil {
+				return err
+			}
+			val.SetInt(int64(d))
+			return nil
+		}
+		n, err := strconv.ParseInt(s, 10, val.Type().Bits())
+		if err != nil {
+			return err
+		}
+		val.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, val.Type().Bits())
+		if err != nil {
+			return err
+		}
+		val.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, val.Type().Bits())
+		if err != nil {
+			return err
+		}
+		val.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported type %s", val.Type())
+	}
+	return nil
+}
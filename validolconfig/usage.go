@@ -0,0 +1,49 @@
+package validolconfig
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+)
+
+// Usage writes a table of the settings of T: the environment variables,
+// the flags, the types, the defaults, the validate tags and the usage tags.
+//
+//	flags.Usage = func() { _ = validolconfig.Usage[Config](flags.Output()) }
+func Usage[T any](w io.Writer) error {
+	settings, err := settingsOf(reflect.TypeFor[T]())
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	tw := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "VARIABLE\tFLAG\tTYPE\tDEFAULT\tRULES\tDESCRIPTION")
+	for _, s := range settings {
+		flagName := s.flag
+		if flagName != "" {
+			flagName = "-" + flagName
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			orDash(s.env), orDash(flagName), s.typ, orDash(s.def), orDash(s.rules), s.usage)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n") {
+		// the rows without a description are padded
+		if _, err := fmt.Fprintln(w, strings.TrimRight(line, " ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
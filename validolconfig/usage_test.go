@@ -0,0 +1,28 @@
+package validolconfig_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cospectrum/validol/validolconfig"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsage(t *testing.T) {
+	t.Parallel()
+
+	var out strings.Builder
+	assert.NoError(t, validolconfig.Usage[appConfig](&out))
+	assert.Equal(t, `VARIABLE  FLAG      TYPE           DEFAULT  RULES                        DESCRIPTION
+PORT      -port     int            8080     gte=1,lte=65535              HTTP port
+DEBUG     -debug    bool           -        -
+TIMEOUT   -         time.Duration  5s       gt=0
+ORIGINS   -         []string       -        min_len=1
+-         -level    *string        -        omitempty,one_of=debug info
+DB_HOST   -db.host  string         -        required                     database host
+DB_PORT   -         int            5432     gte=1,lte=65535
+SINCE     -         time.Time      -        -
+`, out.String())
+
+	assert.Error(t, validolconfig.Usage[string](&out))
+}
@@ -0,0 +1,105 @@
+package validolhttp
+
+import (
+	"encoding"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+)
+
+var textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+
+// bind sets the fields of the struct *dst tagged with `query:"name"`
+// and `path:"name"` from the request. Other types are left as is.
+func bind(r *http.Request, dst any) error {
+	val := reflect.ValueOf(dst).Elem()
+	if val.Kind() != reflect.Struct {
+		return nil
+	}
+	query := r.URL.Query()
+	typ := val.Type()
+	for i := range typ.NumField() {
+		sf := typ.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		if name, ok := sf.Tag.Lookup("path"); ok {
+			if raw := r.PathValue(name); raw != "" {
+				if err := setField(val.Field(i), []string{raw}); err != nil {
+					return badRequest("path parameter %q: %w", name, err)
+				}
+			}
+		}
+		if name, ok := sf.Tag.Lookup("query"); ok {
+			if raw, ok := query[name]; ok {
+				if err := setField(val.Field(i), raw); err != nil {
+					return badRequest("query parameter %q: %w", name, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// setField parses raw into field, a slice gets all the values, other types the first one.
+func setField(field reflect.Value, raw []string) error {
+	if field.Kind() == reflect.Slice && !field.Type().Implements(textUnmarshalerType) &&
+		!reflect.PointerTo(field.Type()).Implements(textUnmarshalerType) {
+		out := reflect.MakeSlice(field.Type(), len(raw), len(raw))
+		for i, s := range raw {
+			if err := setValue(out.Index(i), s); err != nil {
+				return err
+			}
+		}
+		field.Set(out)
+		return nil
+	}
+	return setValue(field, raw[0])
+}
+
+//nolint:cyclop
+func setValue(val reflect.Value, s string) error {
+	if val.Kind() == reflect.Pointer {
+		ptr := reflect.New(val.Type().Elem())
+		if err := setValue(ptr.Elem(), s); err != nil {
+			return err
+		}
+		val.Set(ptr)
+		return nil
+	}
+	if u, ok := val.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(s))
+	}
+	switch val.Kind() {
+	case reflect.String:
+		val.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		val.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, val.Type().Bits())
+		if err != nil {
+			return err
+		}
+		val.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, val.Type().Bits())
+		if err != nil {
+			return err
+		}
+		val.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, val.Type().Bits())
+		if err != nil {
+			return err
+		}
+		val.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported type %s", val.Type())
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package validolhttp_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cospectrum/validol/validolhttp"
+	"github.com/stretchr/testify/assert"
+)
+
+type listUsers struct {
+	Org    string    `path:"org"      validate:"required"`
+	Page   uint      `query:"page"`
+	Limit  *int      `query:"limit"   validate:"lte=100"`
+	Active bool      `query:"active"`
+	Score  float64   `query:"score"`
+	IDs    []int64   `query:"id"`
+	Since  time.Time `query:"since"`
+	Sort   string    `query:"sort"    validate:"omitempty,one_of=name age"`
+}
+
+func TestBind(t *testing.T) {
+	t.Parallel()
+
+	r := httptest.NewRequest(http.MethodGet, "/orgs/acme/users?page=2&limit=10&active=true&score=0.5&id=1&id=2&since=2024-01-02T03:04:05Z&sort=age", nil)
+	r.SetPathValue("org", "acme")
+	q, err := validolhttp.Decode[listUsers](r)
+	assert.NoError(t, err)
+	limit := 10
+	assert.Equal(t, listUsers{
+		Org:    "acme",
+		Page:   2,
+		Limit:  &limit,
+		Active: true,
+		Score:  0.5,
+		IDs:    []int64{1, 2},
+		Since:  time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Sort:   "age",
+	}, q)
+
+	for target, msg := range map[string]string{
+		"/?page=-1":        `query parameter "page": strconv.ParseUint: parsing "-1": invalid syntax`,
+		"/?id=1&id=x":      `query parameter "id": strconv.ParseInt: parsing "x": invalid syntax`,
+		"/?since=tomorrow": `query parameter "since": parsing time "tomorrow" as "2006-01-02T15:04:05Z07:00": cannot parse "tomorrow" as "2006"`,
+	} {
+		r := httptest.NewRequest(http.MethodGet, target, nil)
+		r.SetPathValue("org", "acme")
+		_, err := validolhttp.Decode[listUsers](r)
+		reqErr := requestError(t, err)
+		assert.Equal(t, http.StatusBadRequest, reqErr.Status)
+		assert.Equal(t, msg, reqErr.Error())
+	}
+
+	r = httptest.NewRequest(http.MethodGet, "/?limit=1000", nil)
+	r.SetPathValue("org", "acme")
+	_, err = validolhttp.Decode[listUsers](r)
+	assert.Nil(t, requestErrorOrNil(err))
+	assert.Error(t, err)
+
+	r = httptest.NewRequest(http.MethodGet, "/", nil)
+	_, err = validolhttp.Decode[listUsers](r)
+	assert.Error(t, err)
+
+	_, err = validolhttp.Decode[[]int](httptest.NewRequest(http.MethodGet, "/?id=1", nil))
+	assert.NoError(t, err)
+}
@@ -0,0 +1,144 @@
+// Package validolhttp decodes and validates HTTP requests.
+package validolhttp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	vd "github.com/cospectrum/validol"
+)
+
+// DefaultMaxBytes is the default limit of the request body size.
+const DefaultMaxBytes = 1 << 20
+
+// RequestError is a malformed request, e.g. invalid JSON or query parameter.
+type RequestError struct {
+	Status int
+	Err    error
+}
+
+var _ error = &RequestError{}
+
+func (e *RequestError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+func badRequest(format string, args ...any) error {
+	return &RequestError{Status: http.StatusBadRequest, Err: fmt.Errorf(format, args...)}
+}
+
+type Option func(*config)
+
+type config struct {
+	maxBytes      int64
+	allowUnknown  bool
+	validateOpts  []vd.Option
+	translatorFor func(*http.Request) vd.Translator
+}
+
+func newConfig(opts []Option) config {
+	cfg := config{maxBytes: DefaultMaxBytes, validateOpts: []vd.Option{vd.WithTags(), vd.WithCollectAll()}}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// WithMaxBytes limits the size of the request body, larger bodies fail with 413.
+func WithMaxBytes(n int64) Option {
+	return func(c *config) {
+		c.maxBytes = n
+	}
+}
+
+// AllowUnknownFields accepts JSON objects with fields that are not in the target type.
+func AllowUnknownFields() Option {
+	return func(c *config) {
+		c.allowUnknown = true
+	}
+}
+
+// WithValidateOptions passes opts to validol.ValidateWith after WithTags and WithCollectAll,
+// WithTagName("") disables the tag rules.
+func WithValidateOptions(opts ...vd.Option) Option {
+	return func(c *config) {
+		c.validateOpts = append(c.validateOpts, opts...)
+	}
+}
+
+// WithTranslator renders the errors of a request with the translator returned by fn,
+// e.g. a locale.Bundle matched by the Accept-Language header.
+func WithTranslator(fn func(*http.Request) vd.Translator) Option {
+	return func(c *config) {
+		c.translatorFor = fn
+	}
+}
+
+// Decode decodes the JSON body of r into T, binds its query and path parameters
+// and validates the result with validol.ValidateWith, WithTags and WithCollectAll.
+// A malformed request fails with *RequestError, an invalid value with the validation error.
+func Decode[T any](r *http.Request, opts ...Option) (T, error) {
+	return decode[T](r, newConfig(opts))
+}
+
+func decode[T any](r *http.Request, cfg config) (T, error) {
+	var t T
+	if err := decodeBody(r, &t, cfg); err != nil {
+		return t, err
+	}
+	if err := bind(r, &t); err != nil {
+		return t, err
+	}
+	return t, vd.ValidateWith(t, cfg.validateOpts...)
+}
+
+func decodeBody(r *http.Request, dst any, cfg config) error {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil
+	}
+	if ct := r.Header.Get("Content-Type"); ct != "" {
+		mediaType, _, err := mime.ParseMediaType(ct)
+		if err != nil || (mediaType != "application/json" && !strings.HasSuffix(mediaType, "+json")) {
+			return &RequestError{
+				Status: http.StatusUnsupportedMediaType,
+				Err:    fmt.Errorf("unsupported content type %q", ct),
+			}
+		}
+	}
+	body := io.Reader(r.Body)
+	if cfg.maxBytes > 0 {
+		body = http.MaxBytesReader(nil, r.Body, cfg.maxBytes)
+	}
+	dec := json.NewDecoder(body)
+	if !cfg.allowUnknown {
+		dec.DisallowUnknownFields()
+	}
+	err := vd.DecodeJSON(dec, dst)
+	if err == nil {
+		if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+			return badRequest("body must contain a single JSON value")
+		}
+		return nil
+	}
+	if errors.Is(err, io.EOF) {
+		// an empty body
+		return nil
+	}
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return &RequestError{
+			Status: http.StatusRequestEntityTooLarge,
+			Err:    fmt.Errorf("body is larger than %d bytes", tooLarge.Limit),
+		}
+	}
+	return badRequest("invalid JSON body: %w", err)
+}
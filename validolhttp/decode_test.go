@@ -0,0 +1,120 @@
+package validolhttp_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	vd "github.com/cospectrum/validol"
+	"github.com/cospectrum/validol/validolhttp"
+	"github.com/stretchr/testify/assert"
+)
+
+type createUser struct {
+	Name  string   `json:"name"  validate:"required"`
+	Age   int      `json:"age"   validate:"gte=18"`
+	Tags  []string `json:"tags"`
+	Dry   bool     `json:"-"     query:"dry_run"`
+	OrgID int      `json:"-"     path:"org"      validate:"gt=0"`
+}
+
+func newRequest(body string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/orgs/1/users", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	r.SetPathValue("org", "1")
+	return r
+}
+
+func requestError(t *testing.T, err error) *validolhttp.RequestError {
+	t.Helper()
+	var reqErr *validolhttp.RequestError
+	if !errors.As(err, &reqErr) {
+		t.Fatalf("expected *RequestError, got %v", err)
+	}
+	return reqErr
+}
+
+func TestDecode(t *testing.T) {
+	t.Parallel()
+
+	u, err := validolhttp.Decode[createUser](newRequest(`{"name": "John", "age": 30, "tags": ["a"]}`))
+	assert.NoError(t, err)
+	assert.Equal(t, createUser{Name: "John", Age: 30, Tags: []string{"a"}, OrgID: 1}, u)
+
+	_, err = validolhttp.Decode[createUser](newRequest(`{"name": "John", "age": 17}`))
+	var verr *vd.ValidationError
+	if assert.True(t, errors.As(err, &verr)) {
+		assert.Equal(t, "/age", verr.Path.JSONPointer())
+	}
+
+	_, err = validolhttp.Decode[createUser](newRequest(`{"name": "", "age": 1}`))
+	errs := vd.Errors(err)
+	if assert.Len(t, errs, 2) {
+		assert.Equal(t, "/name", errs[0].Path.JSONPointer())
+		assert.Equal(t, "/age", errs[1].Path.JSONPointer())
+	}
+	_, err = validolhttp.Decode[createUser](newRequest(`{"name": "", "age": 1}`), validolhttp.WithValidateOptions(vd.WithTagName("")))
+	assert.NoError(t, err)
+
+	type member struct {
+		Name string `json:"name" validate:"required"`
+	}
+	type createTeam struct {
+		Members []vd.Validated[member] `json:"members"`
+	}
+	_, err = validolhttp.Decode[createTeam](newRequest(`{"members": [{"name": "a"}, {"name": ""}, {"name": "b"}]}`))
+	if assert.True(t, errors.As(err, &verr)) {
+		assert.Equal(t, "/members/1/name", verr.Path.JSONPointer())
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	_, err = validolhttp.Decode[createUser](r)
+	assert.Error(t, err)
+	assert.Nil(t, requestErrorOrNil(err))
+}
+
+func requestErrorOrNil(err error) *validolhttp.RequestError {
+	var reqErr *validolhttp.RequestError
+	if errors.As(err, &reqErr) {
+		return reqErr
+	}
+	return nil
+}
+
+func TestDecodeMalformed(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		body   string
+		status int
+		msg    string
+	}{
+		{`{"name": "John", "age": 30, "admin": true}`, http.StatusBadRequest, `invalid JSON body: json: unknown field "admin"`},
+		{`{"name": `, http.StatusBadRequest, "invalid JSON body: unexpected EOF"},
+		{`{"name": 1}`, http.StatusBadRequest, "invalid JSON body: json: cannot unmarshal number into Go struct field createUser.name of type string"},
+		{`{"name": "John", "age": 30} {}`, http.StatusBadRequest, "body must contain a single JSON value"},
+		{`{"name": "` + strings.Repeat("a", 100) + `"}`, http.StatusRequestEntityTooLarge, "body is larger than 64 bytes"},
+	}
+	for _, tc := range tests {
+		_, err := validolhttp.Decode[createUser](newRequest(tc.body), validolhttp.WithMaxBytes(64))
+		reqErr := requestError(t, err)
+		assert.Equal(t, tc.status, reqErr.Status, tc.body)
+		assert.Equal(t, tc.msg, reqErr.Error(), tc.body)
+	}
+
+	u, err := validolhttp.Decode[createUser](newRequest(`{"name": "John", "age": 30, "admin": true}`), validolhttp.AllowUnknownFields())
+	assert.NoError(t, err)
+	assert.Equal(t, "John", u.Name)
+
+	r := newRequest(`name=John`)
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	_, err = validolhttp.Decode[createUser](r)
+	assert.Equal(t, http.StatusUnsupportedMediaType, requestError(t, err).Status)
+
+	r = newRequest(`{"name": "John", "age": 30}`)
+	r.Header.Set("Content-Type", "application/merge-patch+json; charset=utf-8")
+	_, err = validolhttp.Decode[createUser](r)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,48 @@
+package validolhttp
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/cospectrum/validol/problem"
+)
+
+// Handler decodes and validates T with Decode before calling fn.
+// On failure it responds with the problem details of WriteError instead.
+func Handler[T any](fn func(w http.ResponseWriter, r *http.Request, t T), opts ...Option) http.Handler {
+	cfg := newConfig(opts)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t, err := decode[T](r, cfg)
+		if err != nil {
+			writeError(w, r, err, cfg)
+			return
+		}
+		fn(w, r, t)
+	})
+}
+
+// WriteError responds with the problem details of err,
+// 400 (or the status of the *RequestError) for a malformed request
+// and 422 with an entry per field for a validation error.
+func WriteError(w http.ResponseWriter, r *http.Request, err error, opts ...Option) {
+	writeError(w, r, err, newConfig(opts))
+}
+
+func writeError(w http.ResponseWriter, r *http.Request, err error, cfg config) {
+	var details *problem.Details
+	var reqErr *RequestError
+	if errors.As(err, &reqErr) {
+		details = problem.New(nil,
+			problem.WithStatus(reqErr.Status),
+			problem.WithTitle(http.StatusText(reqErr.Status)),
+			problem.WithDetail(reqErr.Error()),
+		)
+	} else {
+		var opts []problem.Option
+		if cfg.translatorFor != nil {
+			opts = append(opts, problem.WithTranslator(cfg.translatorFor(r)))
+		}
+		details = problem.New(err, opts...)
+	}
+	_ = details.Write(w)
+}
@@ -0,0 +1,88 @@
+package validolhttp_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	vd "github.com/cospectrum/validol"
+	"github.com/cospectrum/validol/locale"
+	"github.com/cospectrum/validol/problem"
+	"github.com/cospectrum/validol/validolhttp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler(t *testing.T) {
+	t.Parallel()
+
+	bundle := locale.Default()
+	mux := http.NewServeMux()
+	mux.Handle("POST /orgs/{org}/users", validolhttp.Handler(func(w http.ResponseWriter, _ *http.Request, u createUser) {
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(u)
+	},
+		validolhttp.WithTranslator(func(r *http.Request) vd.Translator {
+			return bundle.Match(r.Header.Get("Accept-Language"))
+		}),
+	))
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	post := func(path, body, lang string) (*http.Response, problem.Details) {
+		t.Helper()
+		req, err := http.NewRequest(http.MethodPost, srv.URL+path, strings.NewReader(body))
+		assert.NoError(t, err)
+		req.Header.Set("Accept-Language", lang)
+		resp, err := srv.Client().Do(req)
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		defer resp.Body.Close()
+		var details problem.Details
+		if resp.Header.Get("Content-Type") == problem.ContentType {
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&details))
+		}
+		return resp, details
+	}
+
+	resp, _ := post("/orgs/1/users", `{"name": "John", "age": 30}`, "")
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	resp, details := post("/orgs/0/users", `{"age": 1}`, "ru, en;q=0.5")
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	assert.Equal(t, []problem.Error{
+		{Pointer: "/name", Code: "required", Detail: "обязательное поле"},
+		{Pointer: "/age", Code: "gte", Detail: "должно быть не меньше 18", Params: map[string]any{"val": float64(18)}},
+		{Pointer: "", Code: "gt", Detail: "должно быть больше 0", Params: map[string]any{"val": float64(0)}},
+	}, details.Errors)
+
+	resp, details = post("/orgs/1/users", `{"name": "", "age": 17}`, "")
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	assert.Equal(t, []problem.Error{
+		{Pointer: "/name", Code: "required", Detail: "is required"},
+		{Pointer: "/age", Code: "gte", Detail: "must be greater than or equal to 18", Params: map[string]any{"val": float64(18)}},
+	}, details.Errors)
+
+	resp, details = post("/orgs/x/users", `{}`, "")
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, `path parameter "org": strconv.ParseInt: parsing "x": invalid syntax`, details.Detail)
+	assert.Equal(t, "Bad Request", details.Title)
+	assert.Empty(t, details.Errors)
+
+	resp, details = post("/orgs/1/users", `[`, "")
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, "invalid JSON body: unexpected EOF", details.Detail)
+}
+
+func TestWriteError(t *testing.T) {
+	t.Parallel()
+
+	rec := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	validolhttp.WriteError(rec, r, vd.Gt(1)(0))
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Equal(t, problem.ContentType, rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), `"detail":"must be greater than 1"`)
+}
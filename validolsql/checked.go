@@ -0,0 +1,53 @@
+// Package validolsql validates the values read from and written to a database/sql database.
+package validolsql
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"reflect"
+
+	vd "github.com/cospectrum/validol"
+)
+
+// Checked is a value of T validated with validol.ValidateWith and WithTags when it is scanned
+// from a row or converted to a query argument, so an invalid value never reaches the driver.
+// NULL is scanned as the zero value of T, use a pointer for a nullable column,
+// a nil pointer is valid.
+type Checked[T any] struct {
+	V T
+}
+
+var (
+	_ sql.Scanner   = &Checked[int]{}
+	_ driver.Valuer = Checked[int]{}
+)
+
+// Scan converts src like sql.Null[T] and validates it.
+// database/sql reports the failure with the name of the column.
+func (c *Checked[T]) Scan(src any) error {
+	var n sql.Null[T]
+	if err := n.Scan(src); err != nil {
+		return err
+	}
+	if err := validate(n.V); err != nil {
+		return err
+	}
+	c.V = n.V
+	return nil
+}
+
+// Value validates V and converts it with driver.DefaultParameterConverter.
+func (c Checked[T]) Value() (driver.Value, error) {
+	if err := validate(c.V); err != nil {
+		return nil, err
+	}
+	return driver.DefaultParameterConverter.ConvertValue(c.V)
+}
+
+// validate validates t, a nil pointer is NULL and is valid.
+func validate[T any](t T) error {
+	if val := reflect.ValueOf(&t).Elem(); val.Kind() == reflect.Pointer && val.IsNil() {
+		return nil
+	}
+	return vd.ValidateWith(t, vd.WithTags())
+}
@@ -0,0 +1,65 @@
+package validolsql_test
+
+import (
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	vd "github.com/cospectrum/validol"
+	"github.com/cospectrum/validol/validolsql"
+	"github.com/stretchr/testify/assert"
+)
+
+type email string
+
+func (e email) Validate() error {
+	return vd.Email(string(e))
+}
+
+func TestCheckedScan(t *testing.T) {
+	t.Parallel()
+
+	db, _ := openFake([]string{"email", "manager"},
+		[]driver.Value{"a@b.c", nil},
+		[]driver.Value{[]byte("bad"), "x@y.z"},
+	)
+	rows, err := db.Query("SELECT email, manager FROM users")
+	assert.NoError(t, err)
+	defer rows.Close()
+
+	var (
+		addr    validolsql.Checked[email]
+		manager validolsql.Checked[*email]
+	)
+	assert.True(t, rows.Next())
+	assert.NoError(t, rows.Scan(&addr, &manager))
+	assert.Equal(t, email("a@b.c"), addr.V)
+	assert.Nil(t, manager.V)
+
+	assert.True(t, rows.Next())
+	err = rows.Scan(&addr, &manager)
+	assert.ErrorContains(t, err, `name "email"`)
+	var verr *vd.ValidationError
+	assert.True(t, errors.As(err, &verr))
+	assert.Equal(t, "Email", verr.Rule)
+	assert.Equal(t, email("a@b.c"), addr.V)
+
+	var n validolsql.Checked[int]
+	assert.Error(t, n.Scan("x"))
+}
+
+func TestCheckedValue(t *testing.T) {
+	t.Parallel()
+
+	db, fake := openFake(nil)
+	_, err := db.Exec("INSERT INTO users VALUES (?, ?)",
+		validolsql.Checked[email]{V: "a@b.c"}, validolsql.Checked[*email]{})
+	assert.NoError(t, err)
+
+	_, err = db.Exec("INSERT INTO users VALUES (?)", validolsql.Checked[email]{V: "bad"})
+	var verr *vd.ValidationError
+	assert.True(t, errors.As(err, &verr))
+	assert.Equal(t, "Email", verr.Rule)
+
+	assert.Equal(t, [][]driver.Value{{"a@b.c", nil}}, fake.execs)
+}
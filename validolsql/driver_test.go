@@ -0,0 +1,102 @@
+package validolsql_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync"
+)
+
+// fakeDB is a driver that returns the same rows for every query
+// and records the arguments of the executed statements.
+type fakeDB struct {
+	columns []string
+	rows    [][]driver.Value
+
+	mu    sync.Mutex
+	execs [][]driver.Value
+}
+
+func openFake(columns []string, rows ...[]driver.Value) (*sql.DB, *fakeDB) {
+	db := &fakeDB{columns: columns, rows: rows}
+	return sql.OpenDB(db), db
+}
+
+func (db *fakeDB) Connect(context.Context) (driver.Conn, error) {
+	return fakeConn{db}, nil
+}
+
+func (db *fakeDB) Driver() driver.Driver {
+	return fakeDriver{db}
+}
+
+type fakeDriver struct {
+	db *fakeDB
+}
+
+func (d fakeDriver) Open(string) (driver.Conn, error) {
+	return fakeConn(d), nil
+}
+
+type fakeConn struct {
+	db *fakeDB
+}
+
+func (c fakeConn) Prepare(string) (driver.Stmt, error) {
+	return fakeStmt(c), nil
+}
+
+func (fakeConn) Close() error {
+	return nil
+}
+
+func (fakeConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("fake: transactions are not supported")
+}
+
+type fakeStmt struct {
+	db *fakeDB
+}
+
+func (fakeStmt) Close() error {
+	return nil
+}
+
+func (fakeStmt) NumInput() int {
+	return -1
+}
+
+func (s fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	s.db.execs = append(s.db.execs, args)
+	return driver.RowsAffected(1), nil
+}
+
+func (s fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	return &fakeRows{db: s.db}, nil
+}
+
+type fakeRows struct {
+	db   *fakeDB
+	next int
+}
+
+func (r *fakeRows) Columns() []string {
+	return r.db.columns
+}
+
+func (*fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.next == len(r.db.rows) {
+		return io.EOF
+	}
+	copy(dest, r.db.rows[r.next])
+	r.next++
+	return nil
+}
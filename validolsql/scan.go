@@ -0,0 +1,144 @@
+package validolsql
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	vd "github.com/cospectrum/validol"
+)
+
+var (
+	scannerType = reflect.TypeFor[sql.Scanner]()
+	timeType    = reflect.TypeFor[time.Time]()
+)
+
+// ColumnError is an invalid value of a column.
+type ColumnError struct {
+	Column string
+	// Err is the *validol.ValidationError, its path is relative to the column.
+	Err error
+}
+
+var _ error = &ColumnError{}
+
+func (e *ColumnError) Error() string {
+	return fmt.Sprintf("column %q: %v", e.Column, e.Err)
+}
+
+func (e *ColumnError) Unwrap() error {
+	return e.Err
+}
+
+// ScanValidated scans the current row into T and validates it with
+// validol.ValidateWith, WithTags and WithCollectAll, opts are passed to ValidateWith after them.
+// The columns are matched to the fields of a struct by the `db:"name"` tag
+// or case-insensitively by the field name, other types are scanned from a single column.
+// The failures of the fields are reported as *ColumnError.
+func ScanValidated[T any](rows *sql.Rows, opts ...vd.Option) (T, error) {
+	var t T
+	cols, err := rows.Columns()
+	if err != nil {
+		return t, err
+	}
+	fields, err := fieldsOf(reflect.TypeFor[T](), cols)
+	if err != nil {
+		return t, err
+	}
+	val := reflect.ValueOf(&t).Elem()
+	dest := make([]any, len(cols))
+	for i := range cols {
+		if fields == nil {
+			dest[i] = val.Addr().Interface()
+			continue
+		}
+		dest[i] = val.Field(fields[i]).Addr().Interface()
+	}
+	if err := rows.Scan(dest...); err != nil {
+		return t, err
+	}
+	err = vd.ValidateWith(t, append([]vd.Option{vd.WithTags(), vd.WithCollectAll()}, opts...)...)
+	if err == nil || fields == nil {
+		return t, err
+	}
+	var errs []error
+	for _, verr := range vd.Errors(err) {
+		col := columnOf(verr.Path, val.Type(), cols, fields)
+		if col < 0 {
+			errs = append(errs, verr)
+			continue
+		}
+		rel := *verr
+		rel.Path = verr.Path[1:]
+		errs = append(errs, &ColumnError{Column: cols[col], Err: &rel})
+	}
+	return t, errors.Join(errs...)
+}
+
+// ScanAllValidated scans and validates the rest of the rows with ScanValidated and closes them.
+// It stops at the first failure, which is prefixed by the index of the row.
+func ScanAllValidated[T any](rows *sql.Rows, opts ...vd.Option) ([]T, error) {
+	defer rows.Close()
+	var out []T
+	for rows.Next() {
+		t, err := ScanValidated[T](rows, opts...)
+		if err != nil {
+			return out, fmt.Errorf("row %d: %w", len(out), err)
+		}
+		out = append(out, t)
+	}
+	return out, rows.Err()
+}
+
+// fieldsOf returns the indexes of the struct fields of cols,
+// or nil if typ is scanned from a single column.
+func fieldsOf(typ reflect.Type, cols []string) ([]int, error) {
+	if typ.Kind() != reflect.Struct || typ == timeType || reflect.PointerTo(typ).Implements(scannerType) {
+		if len(cols) != 1 {
+			return nil, fmt.Errorf("validolsql: %s is scanned from 1 column, got %d", typ, len(cols))
+		}
+		return nil, nil
+	}
+	out := make([]int, len(cols))
+	for i, col := range cols {
+		out[i] = -1
+		for j := range typ.NumField() {
+			sf := typ.Field(j)
+			if sf.IsExported() && columnName(sf) == strings.ToLower(col) {
+				out[i] = j
+				break
+			}
+		}
+		if out[i] < 0 {
+			return nil, fmt.Errorf("validolsql: %s has no field for column %q", typ, col)
+		}
+	}
+	return out, nil
+}
+
+func columnName(sf reflect.StructField) string {
+	if name, ok := sf.Tag.Lookup("db"); ok {
+		return strings.ToLower(name)
+	}
+	return strings.ToLower(sf.Name)
+}
+
+// columnOf returns the index of the column of the field at path, or -1.
+func columnOf(path vd.Path, typ reflect.Type, cols []string, fields []int) int {
+	if len(path) == 0 || path[0].Kind != vd.PathField {
+		return -1
+	}
+	sf, ok := typ.FieldByName(path[0].Name)
+	if !ok || len(sf.Index) != 1 {
+		return -1
+	}
+	for i := range cols {
+		if fields[i] == sf.Index[0] {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,95 @@
+package validolsql_test
+
+import (
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	vd "github.com/cospectrum/validol"
+	"github.com/cospectrum/validol/validolsql"
+	"github.com/stretchr/testify/assert"
+)
+
+type user struct {
+	ID      int64  `validate:"gt=0"`
+	Email   email  `db:"email_address"`
+	Name    string `validate:"required"`
+	Manager *email
+	Note    string
+}
+
+func TestScanValidated(t *testing.T) {
+	t.Parallel()
+
+	cols := []string{"id", "email_address", "NAME", "manager"}
+	db, _ := openFake(cols,
+		[]driver.Value{int64(1), "a@b.c", "a", nil},
+		[]driver.Value{int64(2), "x@y.z", "x", "m@b.c"},
+	)
+	rows, err := db.Query("SELECT id, email_address, name, manager FROM users")
+	assert.NoError(t, err)
+	users, err := validolsql.ScanAllValidated[user](rows)
+	assert.NoError(t, err)
+	m := email("m@b.c")
+	assert.Equal(t, []user{
+		{ID: 1, Email: "a@b.c", Name: "a"},
+		{ID: 2, Email: "x@y.z", Name: "x", Manager: &m},
+	}, users)
+
+	db, _ = openFake(cols,
+		[]driver.Value{int64(1), "a@b.c", "a", nil},
+		[]driver.Value{int64(0), "bad", "", "bad"},
+	)
+	rows, err = db.Query("SELECT id, email_address, name, manager FROM users")
+	assert.NoError(t, err)
+	users, err = validolsql.ScanAllValidated[user](rows)
+	assert.Len(t, users, 1)
+	assert.Equal(t, `row 1: column "id": validol.Gt(0)(0) failed
+column "email_address": validol.Email("bad") failed
+column "NAME": validol.Required() failed
+column "manager": validol.Email("bad") failed`, err.Error())
+
+	var colErr *validolsql.ColumnError
+	assert.True(t, errors.As(err, &colErr))
+	assert.Equal(t, "id", colErr.Column)
+	errs := vd.Errors(err)
+	assert.Len(t, errs, 4)
+	assert.Equal(t, "Gt", errs[0].Rule)
+	assert.Empty(t, errs[0].Path)
+}
+
+func TestScanValidatedColumns(t *testing.T) {
+	t.Parallel()
+
+	db, _ := openFake([]string{"email"}, []driver.Value{"bad"})
+	rows, err := db.Query("SELECT email FROM users")
+	assert.NoError(t, err)
+	defer rows.Close()
+	assert.True(t, rows.Next())
+	_, err = validolsql.ScanValidated[email](rows)
+	assert.Equal(t, "Email", asValidationError(t, err).Rule)
+
+	db, _ = openFake([]string{"id", "unknown"}, []driver.Value{int64(1), "x"})
+	rows, err = db.Query("SELECT id, unknown FROM users")
+	assert.NoError(t, err)
+	_, err = validolsql.ScanAllValidated[user](rows)
+	assert.ErrorContains(t, err, `has no field for column "unknown"`)
+
+	rows, err = db.Query("SELECT id, unknown FROM users")
+	assert.NoError(t, err)
+	_, err = validolsql.ScanAllValidated[int64](rows)
+	assert.ErrorContains(t, err, "int64 is scanned from 1 column, got 2")
+
+	db, _ = openFake([]string{"id"}, []driver.Value{"x"})
+	rows, err = db.Query("SELECT id FROM users")
+	assert.NoError(t, err)
+	_, err = validolsql.ScanAllValidated[user](rows)
+	assert.ErrorContains(t, err, `name "id"`)
+}
+
+func asValidationError(t *testing.T, err error) *vd.ValidationError {
+	t.Helper()
+	var verr *vd.ValidationError
+	assert.True(t, errors.As(err, &verr))
+	return verr
+}
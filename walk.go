@@ -1,60 +1,412 @@
 package validol
 
-import "reflect"
+import (
+	"context"
+	"errors"
+	"maps"
+	"reflect"
+	"slices"
+	"sync"
+)
 
-func walkDescendants[T any](in T) error {
-	return validationWalk(in, false)
+var ErrMaxDepth = errors.New("validol: max depth exceeded")
+
+type walker struct {
+	ctx        context.Context //nolint:containedctx // the walker lives for a single call
+	plans      *planner
+	collectAll bool
+	maxDepth   int
+	// groups are the active validation groups
+	groups []string
+	// mask holds the provided descendants of the current value
+	mask fieldMask
+	// sem bounds the number of extra goroutines of a concurrent walk
+	sem chan struct{}
+
+	depth int
+	// ancestors are the references on the current path
+	ancestors map[reference]struct{}
+	// methodCtx is passed to the ValidateCtx methods, it carries the walker, see continueFrom
+	methodCtx context.Context //nolint:containedctx // built once per walker
+}
+
+type reference struct {
+	typ reflect.Type
+	ptr uintptr
+	len int
+}
+
+func newWalker(ctx context.Context, cfg config) *walker {
+	return newWalkerWith(ctx, cfg, plannerFor(cfg))
+}
+
+func newWalkerWith(ctx context.Context, cfg config, plans *planner) *walker {
+	ctx = cfg.withGroups(ctx)
+	w := &walker{
+		ctx:        ctx,
+		plans:      plans,
+		collectAll: cfg.collectAll,
+		maxDepth:   cfg.maxDepth,
+		groups:     activeGroups(ctx),
+		mask:       cfg.partial,
+	}
+	if cfg.concurrency > 1 {
+		w.sem = make(chan struct{}, cfg.concurrency-1)
+	}
+	w.continueFrom(ctx)
+	return w
+}
+
+// walkerKey is the context key of the walker that calls a ValidateCtx method.
+type walkerKey struct{}
+
+// contextForMethod returns the context of the ValidateCtx methods,
+// a WalkCtx called with it continues the walk of w.
+func (w *walker) contextForMethod() context.Context {
+	if w.methodCtx == nil {
+		w.methodCtx = context.WithValue(w.ctx, walkerKey{}, w)
+	}
+	return w.methodCtx
 }
 
-func walk[T any](in T) error {
-	return validationWalk(in, true)
+// continueFrom continues the walk that called the ValidateCtx method with ctx, if any:
+// w starts at its depth and skips its ancestors.
+// The caller is blocked in the method, so its state can be read.
+func (w *walker) continueFrom(ctx context.Context) {
+	parent, ok := ctx.Value(walkerKey{}).(*walker)
+	if !ok {
+		return
+	}
+	w.depth = parent.depth
+	w.ancestors = maps.Clone(parent.ancestors)
+}
+
+func (w *walker) walkDescendants(val reflect.Value) error {
+	return w.validationWalk(val, false)
+}
+
+func (w *walker) walk(val reflect.Value) error {
+	return w.validationWalk(val, true)
 }
 
 //nolint:cyclop
-func validationWalk[T any](in T, validateItself bool) error {
-	val := toReflectValue(in)
+func (w *walker) validationWalk(val reflect.Value, validateItself bool) error {
+	if err := w.ctx.Err(); err != nil {
+		return err
+	}
 	if isNil(val) {
 		return nil
 	}
-	if validateItself && val.CanInterface() {
-		if v, ok := val.Interface().(Validatable); ok {
+	plan := w.plans.planOf(val.Type())
+	if plan.skip {
+		return nil
+	}
+	if ref, ok := referenceOf(val); ok {
+		_, seen := w.ancestors[ref]
+		// a cycle, the value is already being validated,
+		// unless it is the root of a walk called by its own ValidateCtx method
+		if seen && validateItself {
+			return nil
+		}
+		if !seen {
+			if w.ancestors == nil {
+				w.ancestors = make(map[reference]struct{})
+			}
+			w.ancestors[ref] = struct{}{}
+			defer delete(w.ancestors, ref)
+		}
+	}
+	if plan.refined && val.CanInterface() {
+		return val.Interface().(refinedValue).checkRefined() //nolint:forcetypeassert // the plan checked the type
+	}
+	// a partially provided value is not validated as a whole
+	if validateItself && plan.validatable && w.mask == nil && val.CanInterface() {
+		switch v := val.Interface().(type) {
+		case ValidatableCtx:
+			return v.ValidateCtx(w.contextForMethod())
+		case Validatable:
 			return v.Validate()
 		}
 	}
 
 	switch val.Kind() {
 	case reflect.Pointer:
-		return validationWalk(val.Elem(), validateItself)
+		return w.validationWalk(val.Elem(), validateItself)
 	case reflect.Interface:
-		return walk(val.Elem())
+		return w.walk(val.Elem())
 	case reflect.Array, reflect.Slice:
-		for i := range val.Len() {
-			item := val.Index(i)
-			if err := walk(item); err != nil {
-				return err
-			}
+		return w.walkList(val)
+	case reflect.Map:
+		return w.walkMap(val)
+	case reflect.Struct:
+		return w.walkStruct(val, plan)
+	default:
+		return nil
+	}
+}
+
+func referenceOf(val reflect.Value) (reference, bool) {
+	switch val.Kind() {
+	case reflect.Pointer, reflect.Map:
+		return reference{typ: val.Type(), ptr: val.Pointer()}, true
+	case reflect.Slice:
+		if val.Len() == 0 {
+			return reference{}, false
 		}
+		return reference{typ: val.Type(), ptr: val.Pointer(), len: val.Len()}, true
+	default:
+		return reference{}, false
+	}
+}
+
+// enter descends one level deeper, the caller must call leave.
+func (w *walker) enter() error {
+	if w.maxDepth > 0 && w.depth >= w.maxDepth {
+		return ErrMaxDepth
+	}
+	w.depth++
+	return nil
+}
+
+func (w *walker) leave() {
+	w.depth--
+}
+
+func (w *walker) walkList(val reflect.Value) error {
+	if w.plans.planOf(val.Type().Elem()).skip {
 		return nil
-	case reflect.Map:
-		it := val.MapRange()
-		for it.Next() {
-			if err := walk(it.Key()); err != nil {
-				return err
+	}
+	if err := w.enter(); err != nil {
+		return err
+	}
+	defer w.leave()
+	if w.sem != nil && val.Len() > 1 {
+		children := make([]child, 0, val.Len())
+		for i := range val.Len() {
+			children = append(children, child{val.Index(i), indexElem(i), w.mask})
+		}
+		return w.walkConcurrently(children)
+	}
+	errs := w.newCollector()
+	for i := range val.Len() {
+		if errs.add(w.walk(val.Index(i)), indexElem(i)) {
+			break
+		}
+	}
+	return errs.err()
+}
+
+func (w *walker) walkMap(val reflect.Value) error {
+	walkKeys := !w.plans.planOf(val.Type().Key()).skip
+	walkValues := !w.plans.planOf(val.Type().Elem()).skip
+	if err := w.enter(); err != nil {
+		return err
+	}
+	defer w.leave()
+	mask := w.mask
+	defer func() { w.mask = mask }()
+	keys := sortedMapKeys(val)
+	if w.mask != nil {
+		keys = slices.DeleteFunc(keys, func(key reflect.Value) bool {
+			_, ok := mask.entry(key)
+			return !ok
+		})
+	}
+	if w.sem != nil && len(keys) > 1 {
+		children := make([]child, 0, 2*len(keys))
+		for _, key := range keys {
+			sub, _ := mask.entry(key)
+			if walkKeys {
+				children = append(children, child{key, mapKeyElem(keyOf(key)), nil})
 			}
-			if err := walk(it.Value()); err != nil {
-				return err
+			if walkValues {
+				children = append(children, child{val.MapIndex(key), mapValueElem(keyOf(key)), sub})
 			}
 		}
+		return w.walkConcurrently(children)
+	}
+	errs := w.newCollector()
+	for _, key := range keys {
+		w.mask = nil
+		if walkKeys && errs.add(w.walk(key), mapKeyElem(keyOf(key))) {
+			break
+		}
+		w.mask, _ = mask.entry(key)
+		if walkValues && errs.add(w.walk(val.MapIndex(key)), mapValueElem(keyOf(key))) {
+			break
+		}
+	}
+	return errs.err()
+}
+
+func (w *walker) walkStruct(val reflect.Value, plan *typePlan) error {
+	if !val.CanInterface() {
+		// reached through an unexported field, nothing can be validated
 		return nil
-	case reflect.Struct:
-		for i := range val.NumField() {
-			field := val.Field(i)
-			if err := walk(field); err != nil {
-				return err
+	}
+	if plan.tagErr != nil {
+		return plan.tagErr
+	}
+	if err := w.enter(); err != nil {
+		return err
+	}
+	defer w.leave()
+	mask := w.mask
+	defer func() { w.mask = mask }()
+	errs := w.newCollector()
+	for _, fp := range plan.fields {
+		sub, ok := mask.field(fp)
+		if !ok {
+			continue
+		}
+		w.mask = sub
+		field := val.Field(fp.index)
+		if len(fp.rules) > 0 {
+			if err := w.validateField(val, field, fp.rules); err != nil {
+				if errs.add(err, fp.elem) {
+					break
+				}
+				continue
+			}
+		}
+		if errs.add(w.walk(field), fp.elem) {
+			break
+		}
+	}
+	return errs.err()
+}
+
+// validateField runs the tag rules of the field that belong to the active groups.
+func (w *walker) validateField(parent, field reflect.Value, rules []compiledField) error {
+	for _, c := range rules {
+		if c.group != "" && !slices.Contains(w.groups, c.group) {
+			continue
+		}
+		if err := c.validate(parent, field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type child struct {
+	val  reflect.Value
+	elem PathElem
+	mask fieldMask
+}
+
+// walkConcurrently walks children using free workers of the pool,
+// and the current goroutine when all of them are busy.
+// In fail-fast mode a failure cancels only the children that follow it,
+// so the reported error is the same as in a sequential walk.
+func (w *walker) walkConcurrently(children []child) error {
+	var (
+		mu          sync.Mutex
+		firstFailed = len(children)
+		cancels     = make([]context.CancelFunc, len(children))
+		results     = make([]error, len(children))
+	)
+	skip := func(i int) bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return i > firstFailed
+	}
+	walkChild := func(i int) {
+		mu.Lock()
+		if i > firstFailed {
+			mu.Unlock()
+			return
+		}
+		ctx, cancel := context.WithCancel(w.ctx)
+		defer cancel()
+		cancels[i] = cancel
+		mu.Unlock()
+
+		sub := *w
+		sub.ctx = ctx
+		sub.methodCtx = nil
+		sub.ancestors = maps.Clone(w.ancestors)
+		sub.mask = children[i].mask
+		results[i] = sub.walk(children[i].val)
+		if results[i] == nil || w.collectAll || w.ctx.Err() != nil {
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if i < firstFailed {
+			firstFailed = i
+			for _, cancel := range cancels[i+1:] {
+				if cancel != nil {
+					cancel()
+				}
 			}
 		}
-		return nil
-	default:
-		return nil
 	}
+
+	var wg sync.WaitGroup
+	for i := range children {
+		if skip(i) || w.ctx.Err() != nil {
+			break
+		}
+		select {
+		case w.sem <- struct{}{}:
+			wg.Add(1)
+			go func() {
+				defer func() {
+					<-w.sem
+					wg.Done()
+				}()
+				walkChild(i)
+			}()
+		default:
+			walkChild(i)
+		}
+	}
+	wg.Wait()
+
+	if err := w.ctx.Err(); err != nil {
+		return err
+	}
+	errs := w.newCollector()
+	for i, err := range results[:min(firstFailed+1, len(results))] {
+		if errs.add(err, children[i].elem) {
+			break
+		}
+	}
+	return errs.err()
+}
+
+func (w *walker) newCollector() *collector {
+	return &collector{all: w.collectAll, ctx: w.ctx}
+}
+
+// collector accumulates the errors of descendants.
+// In fail-fast mode it keeps only the first one.
+type collector struct {
+	all  bool
+	ctx  context.Context //nolint:containedctx // the context of the walk, nil outside of a walk
+	errs []error
+}
+
+// add records err under elem and reports whether the traversal should stop.
+// The traversal stops once the context of the walk is done, its error has no path.
+// A context error of a validator whose walk goes on is an ordinary failure.
+func (c *collector) add(err error, elem PathElem) bool {
+	if err == nil {
+		return false
+	}
+	if c.done() && isContextErr(err) {
+		c.errs = append(c.errs, err)
+		return true
+	}
+	c.errs = append(c.errs, withPath(err, elem))
+	return !c.all || c.done()
+}
+
+func (c *collector) done() bool {
+	return c.ctx != nil && c.ctx.Err() != nil
+}
+
+func (c *collector) err() error {
+	return joinErrors(c.errs)
 }
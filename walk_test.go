@@ -0,0 +1,418 @@
+package validol_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	vd "github.com/cospectrum/validol"
+	"github.com/stretchr/testify/assert"
+)
+
+type qty int
+
+func (q qty) Validate() error {
+	return vd.Gt(0)(int(q))
+}
+
+type sku string
+
+func (s sku) Validate() error {
+	return vd.StartsWith("sku-")(string(s))
+}
+
+type item struct {
+	Qty qty
+}
+
+type order struct {
+	Items map[sku]item
+}
+
+type orders struct {
+	Orders []order
+}
+
+type failing struct{}
+
+var errFailing = errors.New("failing")
+
+func (failing) Validate() error {
+	return errFailing
+}
+
+func TestWalkPath(t *testing.T) {
+	t.Parallel()
+
+	valid := orders{Orders: []order{
+		{Items: map[sku]item{"sku-1": {Qty: 1}}},
+	}}
+	assert.NoError(t, vd.Walk(valid))
+
+	invalidValue := orders{Orders: []order{
+		{Items: map[sku]item{"sku-1": {Qty: 1}}},
+		{},
+		{Items: map[sku]item{"sku-1": {Qty: 0}}},
+	}}
+	err := vd.Walk(invalidValue)
+	verr := asValidationError(t, err)
+	assert.Equal(t, `Orders[2].Items["sku-1"].Qty`, verr.Path.String())
+	assert.Equal(t, vd.Path{
+		{Kind: vd.PathField, Name: "Orders"},
+		{Kind: vd.PathIndex, Index: 2},
+		{Kind: vd.PathField, Name: "Items"},
+		{Kind: vd.PathMapValue, Key: sku("sku-1")},
+		{Kind: vd.PathField, Name: "Qty"},
+	}, verr.Path)
+	assert.Equal(t, "Gt", verr.Rule)
+	assert.Equal(t, `Orders[2].Items["sku-1"].Qty: validol.Gt(0)(0) failed`, err.Error())
+
+	invalidKey := orders{Orders: []order{
+		{Items: map[sku]item{"1": {Qty: 1}}},
+	}}
+	verr = asValidationError(t, vd.Walk(invalidKey))
+	assert.Equal(t, `Orders[0].Items[key:"1"]`, verr.Path.String())
+	assert.Equal(t, vd.PathMapKey, verr.Path[3].Kind)
+
+	ptrs := []*item{{Qty: 1}, {Qty: -1}}
+	verr = asValidationError(t, vd.Walk(&ptrs))
+	assert.Equal(t, "[1].Qty", verr.Path.String())
+
+	iface := struct{ Field any }{Field: []item{{Qty: 0}}}
+	verr = asValidationError(t, vd.Walk(iface))
+	assert.Equal(t, "Field[0].Qty", verr.Path.String())
+}
+
+func TestWalkPathForeignErrors(t *testing.T) {
+	t.Parallel()
+
+	err := vd.Walk(struct{ F []failing }{F: []failing{{}}})
+	verr := asValidationError(t, err)
+	assert.Equal(t, "F[0]", verr.Path.String())
+	assert.Empty(t, verr.Rule)
+	assert.ErrorIs(t, err, errFailing)
+	assert.Equal(t, "F[0]: failing", err.Error())
+
+	joined := struct{ U []User }{U: []User{{Email: "bad"}}}
+	err = vd.Walk(joined)
+	assert.Equal(t, "U[0]: validol.Gte(18)(0) failed\nU[0].Email: validol.Gt(5)(3) failed", err.Error())
+}
+
+func TestWalkCollectAll(t *testing.T) {
+	t.Parallel()
+
+	in := orders{Orders: []order{
+		{Items: map[sku]item{"sku-2": {Qty: 0}, "sku-1": {Qty: -1}, "3": {Qty: 1}}},
+		{},
+		{Items: map[sku]item{"4": {Qty: 0}}},
+	}}
+
+	err := vd.Walk(in)
+	verr := asValidationError(t, err)
+	assert.Len(t, vd.Errors(err), 1)
+	assert.Equal(t, `Orders[0].Items[key:"3"]`, verr.Path.String())
+
+	err = vd.WalkWith(in, vd.WithCollectAll())
+	paths := make([]string, 0)
+	for _, verr := range vd.Errors(err) {
+		paths = append(paths, verr.Path.String())
+	}
+	assert.Equal(t, []string{
+		`Orders[0].Items[key:"3"]`,
+		`Orders[0].Items["sku-1"].Qty`,
+		`Orders[0].Items["sku-2"].Qty`,
+		`Orders[2].Items[key:"4"]`,
+		`Orders[2].Items["4"].Qty`,
+	}, paths)
+
+	for range 10 {
+		assert.Equal(t, err.Error(), vd.WalkWith(in, vd.WithCollectAll()).Error())
+	}
+
+	assert.NoError(t, vd.WalkWith(orders{}, vd.WithCollectAll()))
+}
+
+func TestErrors(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, vd.Errors(nil))
+
+	gtErr := vd.Gt(1)(0)
+	assert.Equal(t, []*vd.ValidationError{asValidationError(t, gtErr)}, vd.Errors(gtErr))
+
+	errs := vd.Errors(errors.Join(gtErr, errFailing, fmt.Errorf("wrapped: %w", gtErr)))
+	assert.Len(t, errs, 3)
+	assert.Equal(t, "Gt", errs[0].Rule)
+	assert.Equal(t, errFailing, errs[1].Err)
+	assert.Equal(t, "Gt", errs[2].Rule)
+}
+
+type benchAddress struct {
+	Street  string
+	City    string
+	Zip     string `validate:"len=5"`
+	Country string
+}
+
+type benchLine struct {
+	SKU      sku
+	Qty      qty
+	Price    float64
+	Discount float64
+	Notes    []string
+	Raw      []byte
+}
+
+type benchOrder struct {
+	ID        uuid4
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Customer  struct {
+		Name    string
+		Email   email
+		Phones  []string
+		Address benchAddress
+	}
+	Lines    []benchLine
+	Meta     map[string]string
+	Payload  []byte
+	Total    float64
+	Currency string
+}
+
+func newBenchOrders(n int) []benchOrder {
+	orders := make([]benchOrder, 0, n)
+	for range n {
+		o := benchOrder{
+			ID:       "57b73598-8764-4ad0-a76a-679bb6640eb1",
+			Meta:     map[string]string{"source": "web", "campaign": "spring"},
+			Payload:  make([]byte, 512),
+			Total:    42,
+			Currency: "EUR",
+		}
+		o.Customer.Name = "John"
+		o.Customer.Email = "john@mail.com"
+		o.Customer.Phones = []string{"+100", "+200"}
+		o.Customer.Address = benchAddress{Street: "Main", City: "Berlin", Zip: "10115", Country: "DE"}
+		for range 10 {
+			o.Lines = append(o.Lines, benchLine{
+				SKU:   "sku-1",
+				Qty:   1,
+				Price: 4.2,
+				Notes: []string{"gift", "fragile"},
+				Raw:   make([]byte, 64),
+			})
+		}
+		orders = append(orders, o)
+	}
+	return orders
+}
+
+func BenchmarkWalk(b *testing.B) {
+	orders := newBenchOrders(100)
+	b.ResetTimer()
+	for range b.N {
+		if err := vd.WalkWith(orders, vd.WithTags()); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkWalkPlain(b *testing.B) {
+	type plain struct {
+		A, B, C int
+		D       string
+		E       []byte
+		F       time.Time
+		G       []float64
+	}
+	in := make([]plain, 1000)
+	for i := range in {
+		in[i].E = make([]byte, 128)
+		in[i].G = make([]float64, 16)
+	}
+	b.ResetTimer()
+	for range b.N {
+		if err := vd.Walk(in); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+type plainNode struct {
+	Next  *plainNode
+	Value int
+}
+
+type validatedNode struct {
+	Children []validatedNode
+	Next     *validatedNode
+	Qty      qty
+}
+
+type mutualA struct {
+	B *mutualB
+}
+
+type mutualB struct {
+	A   *mutualA
+	Qty *qty
+}
+
+func TestWalkRecursiveTypes(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, vd.Walk(plainNode{Next: &plainNode{}}))
+
+	tree := validatedNode{Qty: 1, Children: []validatedNode{
+		{Qty: 1, Next: &validatedNode{Qty: 1}},
+		{Qty: 1, Children: []validatedNode{{Qty: 1}}},
+	}}
+	assert.NoError(t, vd.Walk(tree))
+	tree.Children[1].Children[0].Qty = 0
+	verr := asValidationError(t, vd.Walk(tree))
+	assert.Equal(t, "Children[1].Children[0].Qty", verr.Path.String())
+	tree.Children[1].Children[0].Qty = 1
+	tree.Children[0].Next.Qty = 0
+	verr = asValidationError(t, vd.Walk(tree))
+	assert.Equal(t, "Children[0].Next.Qty", verr.Path.String())
+
+	var zero qty
+	verr = asValidationError(t, vd.Walk(mutualA{B: &mutualB{A: &mutualA{B: &mutualB{Qty: &zero}}}}))
+	assert.Equal(t, "B.A.B.Qty", verr.Path.String())
+	verr = asValidationError(t, vd.Walk(mutualB{A: &mutualA{B: &mutualB{Qty: &zero}}}))
+	assert.Equal(t, "A.B.Qty", verr.Path.String())
+}
+
+type entity struct {
+	Name     string `validate:"required"`
+	Parent   *entity
+	Children []*entity
+	Links    map[string]*entity
+	Any      any
+}
+
+func TestWalkCycles(t *testing.T) {
+	t.Parallel()
+
+	root := &entity{Name: "root", Links: map[string]*entity{}}
+	child := &entity{Name: "child", Parent: root}
+	root.Children = []*entity{child, child}
+	root.Links["self"] = root
+	root.Any = root
+	child.Any = []any{root, child}
+
+	assert.NoError(t, vd.WalkWith(root, vd.WithTags()))
+	assert.NoError(t, vd.WalkWith(*root, vd.WithTags()))
+	assert.NoError(t, vd.WalkWith(root, vd.WithConcurrency(4), vd.WithTags()))
+
+	child.Name = ""
+	verr := asValidationError(t, vd.WalkWith(root, vd.WithTags()))
+	assert.Equal(t, "Children[0].Name", verr.Path.String())
+	errs := vd.Errors(vd.WalkWith(root, vd.WithCollectAll(), vd.WithTags()))
+	assert.Len(t, errs, 2)
+	assert.Equal(t, "Children[1].Name", errs[1].Path.String())
+
+	type list []any
+	l := list{nil}
+	l[0] = l
+	assert.NoError(t, vd.Walk(l))
+}
+
+func TestWalkMaxDepth(t *testing.T) {
+	t.Parallel()
+
+	head := &entity{Name: "0"}
+	node := head
+	for range 10 {
+		node.Children = []*entity{{Name: "n"}}
+		node = node.Children[0]
+	}
+
+	assert.NoError(t, vd.WalkWith(head, vd.WithTags()))
+	assert.NoError(t, vd.WalkWith(head, vd.WithMaxDepth(21), vd.WithTags()))
+
+	err := vd.WalkWith(head, vd.WithMaxDepth(20), vd.WithTags())
+	assert.ErrorIs(t, err, vd.ErrMaxDepth)
+	verr := asValidationError(t, err)
+	assert.Equal(t, "Children[0].Children[0].Children[0].Children[0].Children[0].Children[0].Children[0].Children[0].Children[0].Children[0]", verr.Path.String())
+	assert.Contains(t, err.Error(), "validol: max depth exceeded")
+
+	assert.ErrorIs(t, vd.WalkWith([][]qty{{1}}, vd.WithMaxDepth(1)), vd.ErrMaxDepth)
+	assert.NoError(t, vd.WalkWith([][]qty{{1}}, vd.WithMaxDepth(2)))
+}
+
+type selfNode struct {
+	Next *selfNode
+	Qty  qty
+}
+
+func (n selfNode) ValidateCtx(ctx context.Context) error {
+	return vd.WalkCtx(ctx, n)
+}
+
+type asyncNode struct {
+	Next *asyncNode
+	Qty  qty
+}
+
+func (n asyncNode) ValidateCtx(ctx context.Context) error {
+	done := make(chan error)
+	go func() { done <- vd.WalkCtx(ctx, n) }()
+	return <-done
+}
+
+type endless struct {
+	Next *endless
+}
+
+func (endless) ValidateCtx(ctx context.Context) error {
+	return vd.WalkCtx(ctx, endless{Next: &endless{}}, vd.WithMaxDepth(5))
+}
+
+func TestWalkCtxNestedInValidateCtx(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	n := &selfNode{Qty: 1}
+	n.Next = n
+	assert.NoError(t, vd.ValidateCtx(ctx, n))
+	assert.NoError(t, vd.WalkCtx(ctx, []selfNode{*n, *n}, vd.WithConcurrency(4)))
+	n.Qty = 0
+	verr := asValidationError(t, vd.ValidateCtx(ctx, n))
+	assert.Equal(t, "Next.Qty", verr.Path.String())
+
+	a := &asyncNode{Qty: 1}
+	a.Next = a
+	assert.NoError(t, vd.ValidateCtx(ctx, a))
+
+	err := vd.ValidateCtx(ctx, endless{})
+	assert.ErrorIs(t, err, vd.ErrMaxDepth)
+	verr = asValidationError(t, err)
+	assert.Equal(t, "Next.Next.Next.Next.Next", verr.Path.String())
+}
+
+type ptrNode struct {
+	Next *ptrNode
+	Qty  qty
+}
+
+func (n *ptrNode) Validate() error {
+	return vd.Walk(n)
+}
+
+func TestWalkInPointerMethod(t *testing.T) {
+	t.Parallel()
+
+	n := &ptrNode{Qty: 1}
+	n.Next = n
+	assert.NoError(t, vd.Validate(n))
+	assert.NoError(t, vd.Walk([]*ptrNode{n, n}))
+	n.Qty = 0
+	verr := asValidationError(t, vd.Validate(n))
+	assert.Equal(t, "Qty", verr.Path.String())
+	verr = asValidationError(t, vd.Walk(struct{ Node *ptrNode }{n}))
+	assert.Equal(t, "Node.Qty", verr.Path.String())
+}
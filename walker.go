@@ -0,0 +1,58 @@
+package validol
+
+import (
+	"context"
+	"reflect"
+)
+
+// Walker is a reusable Walk configuration with its own cache of traversal plans.
+// It is safe for concurrent use.
+type Walker struct {
+	cfg   config
+	plans *planner
+}
+
+func NewWalker(opts ...Option) *Walker {
+	cfg := newConfig(opts)
+	return &Walker{
+		cfg:   cfg,
+		plans: newPlanner(cfg),
+	}
+}
+
+// Walk is like the package-level Walk, with the dynamic value of t as the root.
+func (w *Walker) Walk(t any) error {
+	return w.WalkCtx(context.Background(), t)
+}
+
+func (w *Walker) WalkCtx(ctx context.Context, t any) error {
+	return newWalkerWith(ctx, w.cfg, w.plans).walkDescendants(reflect.ValueOf(t))
+}
+
+func (w *Walker) Validate(t any) error {
+	if w.cfg.partial != nil {
+		return w.Walk(t)
+	}
+	switch val := t.(type) {
+	case Validatable:
+		return val.Validate()
+	case ValidatableCtx:
+		return val.ValidateCtx(w.cfg.withGroups(context.Background()))
+	default:
+		return w.Walk(t)
+	}
+}
+
+func (w *Walker) ValidateCtx(ctx context.Context, t any) error {
+	if w.cfg.partial != nil {
+		return w.WalkCtx(ctx, t)
+	}
+	switch val := t.(type) {
+	case ValidatableCtx:
+		return val.ValidateCtx(w.cfg.withGroups(ctx))
+	case Validatable:
+		return val.Validate()
+	default:
+		return w.WalkCtx(ctx, t)
+	}
+}
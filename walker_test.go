@@ -0,0 +1,96 @@
+package validol_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+
+	vd "github.com/cospectrum/validol"
+	"github.com/stretchr/testify/assert"
+)
+
+type customTag struct {
+	Name string `rules:"required" validate:"unknown_rule"`
+}
+
+// playgroundDTO has the tags of github.com/go-playground/validator.
+type playgroundDTO struct {
+	Name string `validate:"required,min=3"`
+}
+
+func TestTagsOptIn(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, vd.Walk(playgroundDTO{}))
+	assert.NoError(t, vd.Validate([]playgroundDTO{{}}))
+	var tagErr *vd.TagError
+	assert.True(t, errors.As(vd.ValidateWith(playgroundDTO{}, vd.WithTags()), &tagErr))
+}
+
+func TestWithTagName(t *testing.T) {
+	t.Parallel()
+
+	var tagErr *vd.TagError
+	assert.NoError(t, vd.Walk(customTag{}))
+	assert.True(t, errors.As(vd.WalkWith(customTag{Name: "x"}, vd.WithTags()), &tagErr))
+	assert.NoError(t, vd.WalkWith(customTag{Name: "x"}, vd.WithTags(), vd.WithTagName("")))
+
+	assert.NoError(t, vd.WalkWith(customTag{Name: "x"}, vd.WithTagName("rules")))
+	verr := asValidationError(t, vd.WalkWith(customTag{}, vd.WithTagName("rules")))
+	assert.Equal(t, "Name", verr.Path.String())
+	assert.NoError(t, vd.ValidateWith(customTag{}, vd.WithTagName("none")))
+}
+
+type skipped struct {
+	Qty qty
+}
+
+func TestSkipTypes(t *testing.T) {
+	t.Parallel()
+
+	in := struct {
+		A skipped
+		B []qty
+	}{B: []qty{1}}
+	assert.Error(t, vd.Walk(in))
+	assert.NoError(t, vd.WalkWith(in, vd.SkipTypes(reflect.TypeFor[skipped]())))
+
+	in.B[0] = 0
+	assert.Error(t, vd.WalkWith(in, vd.SkipTypes(reflect.TypeFor[skipped]())))
+	assert.NoError(t, vd.WalkWith(in, vd.SkipTypes(reflect.TypeFor[skipped](), reflect.TypeFor[qty]())))
+	assert.NoError(t, vd.WalkWith(in, vd.SkipTypes(reflect.TypeFor[qty](), reflect.TypeFor[skipped]())))
+	assert.Error(t, vd.WalkWith(in, vd.SkipTypes(reflect.TypeFor[skipped](), reflect.TypeFor[skipped]())))
+	assert.NoError(t, vd.WalkWith(in, vd.WithTags(), vd.SkipTypes(reflect.TypeFor[qty](), reflect.TypeFor[skipped]())))
+}
+
+func TestWalker(t *testing.T) {
+	t.Parallel()
+
+	w := vd.NewWalker(vd.WithCollectAll(), vd.WithTagName("rules"))
+
+	in := []customTag{{}, {Name: "x"}, {}}
+	errs := vd.Errors(w.Walk(in))
+	assert.Len(t, errs, 2)
+	assert.Equal(t, "[2].Name", errs[1].Path.String())
+	assert.Equal(t, w.Walk(in).Error(), w.Validate(in).Error())
+	assert.Equal(t, w.Walk(in).Error(), w.ValidateCtx(context.Background(), in).Error())
+	assert.Equal(t, w.Walk(in).Error(), w.WalkCtx(context.Background(), &in).Error())
+
+	// the dynamic value is the root
+	var ni NonZeroInt
+	assert.NoError(t, w.Walk(ni))
+	assert.Error(t, w.Validate(ni))
+	assert.Error(t, w.ValidateCtx(context.Background(), username("admin")))
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Len(t, vd.Errors(w.Walk(in)), 2)
+		}()
+	}
+	wg.Wait()
+}